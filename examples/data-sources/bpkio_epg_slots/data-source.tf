@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+data "bpkio_epg_slots" "tonight" {
+  path         = "${path.module}/epg/movies.xml"
+  channel      = "movies.example.com"
+  window_start = "2025-06-01T18:00:00Z"
+  window_end   = "2025-06-02T06:00:00Z"
+
+  mapping = {
+    "Evening News" = 12001
+    "Film"         = 12002
+    "Documentary"  = 12003
+  }
+}
+
+resource "bpkio_virtual_channel_schedule" "tonight" {
+  service_id   = 123082
+  window_start = "2025-06-01T18:00:00Z"
+  window_end   = "2025-06-02T06:00:00Z"
+  slots        = data.bpkio_epg_slots.tonight.slots
+}
+
+output "unmapped_programmes" {
+  value = data.bpkio_epg_slots.tonight.unmapped[*].title
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+data "bpkio_service_content_replacement" "this" {
+  id = 123082
+}
+
+output "this_service_url" {
+  value = data.bpkio_service_content_replacement.this.url
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+data "bpkio_source_asset_catalog" "this" {
+  id = 123082
+}
+
+output "this_source" {
+  value = data.bpkio_source_asset_catalog.this
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+data "bpkio_source_origin" "this" {
+  id = 123082
+}
+
+output "this_source_url" {
+  value = data.bpkio_source_origin.this.url
+}
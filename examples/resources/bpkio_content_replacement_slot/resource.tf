@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+resource "bpkio_source_live" "channel" {
+  name = "foobar-test-tf"
+  url  = "https://live.stream/master.m3u8"
+}
+
+resource "bpkio_source_slate" "blackout" {
+  name = "foobar-test-tf-blackout"
+  url  = "http://commondatastorage.googleapis.com/gtv-videos-bucket/sample/ForBiggerEscapes.mp4"
+}
+
+resource "bpkio_service_content_replacement" "blackout" {
+  name = "foobar-test-tf"
+
+  source = {
+    id = bpkio_source_live.channel.id
+  }
+
+  replacement = {
+    id = bpkio_source_slate.blackout.id
+  }
+}
+
+resource "bpkio_content_replacement_slot" "match" {
+  service_id = bpkio_service_content_replacement.blackout.id
+  name       = "Regional blackout"
+  start_time = "2025-06-01T18:30:00+02:00"
+  duration   = 6300
+
+  replacement = {
+    id = bpkio_source_slate.blackout.id
+  }
+}
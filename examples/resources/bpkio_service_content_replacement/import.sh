@@ -0,0 +1,2 @@
+# Content Replacement Service can be imported by specifying the numeric identifier.
+terraform import bpkio_service_content_replacement.example 123
@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+resource "bpkio_source_live" "channel" {
+  name = "foobar-test-tf"
+  url  = "https://live.stream/master.m3u8"
+}
+
+resource "bpkio_source_slate" "blackout" {
+  name = "foobar-test-tf-blackout"
+  url  = "http://commondatastorage.googleapis.com/gtv-videos-bucket/sample/ForBiggerEscapes.mp4"
+}
+
+resource "bpkio_service_content_replacement" "this" {
+  name = "foobar-test-tf"
+  tags = ["blackout"]
+
+  source = {
+    id = bpkio_source_live.channel.id
+  }
+
+  replacement = {
+    id = bpkio_source_slate.blackout.id
+  }
+}
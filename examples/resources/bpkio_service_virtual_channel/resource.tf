@@ -0,0 +1,50 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+resource "bpkio_source_live" "channel" {
+  name = "foobar-test-tf"
+  url  = "https://live.stream/master.m3u8"
+}
+
+resource "bpkio_source_slate" "filler" {
+  name = "foobar-test-tf-filler"
+  url  = "http://commondatastorage.googleapis.com/gtv-videos-bucket/sample/ForBiggerEscapes.mp4"
+}
+
+resource "bpkio_source_adserver" "ads" {
+  name = "foobar-test-tf-ads"
+  url  = "https://ads.example.com/vast"
+}
+
+resource "bpkio_service_virtual_channel" "this" {
+  name = "foobar-test-tf"
+  tags = ["linear"]
+
+  base_live = {
+    id = bpkio_source_live.channel.id
+  }
+
+  ad_break_insertion = {
+    ad_server = {
+      id = bpkio_source_adserver.ads.id
+    }
+    gap_filler = {
+      id = bpkio_source_slate.filler.id
+    }
+  }
+
+  live_ad_preroll = {
+    ad_server = {
+      id = bpkio_source_adserver.ads.id
+    }
+    max_duration = 30
+  }
+}
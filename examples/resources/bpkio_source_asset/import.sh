@@ -0,0 +1,2 @@
+# Asset Source can be imported by specifying the numeric identifier.
+terraform import bpkio_source_asset.example 123
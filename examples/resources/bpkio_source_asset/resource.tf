@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+resource "bpkio_source_asset" "this" {
+  name        = "foobar-test-tf"
+  description = "test asset"
+  url         = "https://origin.broadpeak.io/bpk-vod/voddemo/default/5min/tearsofsteel/manifest.m3u8"
+
+  origin = {
+    custom_headers = [
+      {
+        name  = "X-Origin-Token"
+        value = "secret"
+      },
+    ]
+  }
+}
@@ -0,0 +1,2 @@
+# Asset Catalog Source can be imported by specifying the numeric identifier.
+terraform import bpkio_source_asset_catalog.example 123
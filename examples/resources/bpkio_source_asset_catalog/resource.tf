@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+resource "bpkio_source_asset_catalog" "this" {
+  name         = "foobar-test-tf"
+  description  = "test asset catalog"
+  url          = "https://origin.broadpeak.io/bpk-vod/voddemo/default/5min/"
+  asset_sample = "tearsofsteel/manifest.m3u8"
+}
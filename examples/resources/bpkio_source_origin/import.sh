@@ -0,0 +1,2 @@
+# Origin Source can be imported by specifying the numeric identifier.
+terraform import bpkio_source_origin.example 123
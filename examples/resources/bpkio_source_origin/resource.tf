@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+variable "origin_token" {
+  type      = string
+  sensitive = true
+}
+
+resource "bpkio_source_origin" "this" {
+  name        = "foobar-test-tf"
+  description = "test origin"
+  url         = "https://origin.broadpeak.io/bpk-tv/"
+
+  origin = {
+    custom_headers = [
+      {
+        name  = "X-Origin-Token"
+        value = var.origin_token
+      },
+    ]
+  }
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+resource "bpkio_transcoding_profile" "example" {
+  name = "ads-1080p"
+  content = jsonencode({
+    packaging = {
+      "--hls-client-manifest-version=" = "4"
+    }
+    servicetype = "offline_transcoding"
+    transcoding = {
+      common = {}
+      jobs   = []
+    }
+  })
+}
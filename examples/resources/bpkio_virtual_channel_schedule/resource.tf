@@ -0,0 +1,50 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+resource "bpkio_source_live" "channel" {
+  name = "foobar-test-tf"
+  url  = "https://live.stream/master.m3u8"
+}
+
+resource "bpkio_source_asset" "episodes" {
+  for_each = toset(["s01e01", "s01e02", "s01e03"])
+
+  name = "foobar-test-tf-${each.key}"
+  url  = "https://vod.stream/${each.key}/master.m3u8"
+}
+
+resource "bpkio_service_virtual_channel" "channel" {
+  name = "foobar-test-tf"
+
+  base_live = {
+    id = bpkio_source_live.channel.id
+  }
+}
+
+locals {
+  day = "2025-06-01"
+}
+
+# Plays the three episodes back to back every evening, from 20:00 UTC.
+resource "bpkio_virtual_channel_schedule" "evening" {
+  service_id   = bpkio_service_virtual_channel.channel.id
+  window_start = "${local.day}T00:00:00Z"
+  window_end   = timeadd("${local.day}T00:00:00Z", "24h")
+
+  slots = [
+    for i, episode in ["s01e01", "s01e02", "s01e03"] : {
+      name           = episode
+      start_time     = timeadd("${local.day}T20:00:00Z", "${i * 45}m")
+      duration       = 45 * 60
+      replacement_id = bpkio_source_asset.episodes[episode].id
+    }
+  ]
+}
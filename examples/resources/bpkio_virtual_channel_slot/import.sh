@@ -0,0 +1,2 @@
+# Virtual Channel Slot can be imported by specifying the numeric identifiers of the service and the slot.
+terraform import bpkio_virtual_channel_slot.example 123/456
@@ -0,0 +1,39 @@
+terraform {
+  required_providers {
+    bpkio = {
+      source = "bashou/bpkio"
+    }
+  }
+}
+
+provider "bpkio" {
+}
+
+resource "bpkio_source_live" "channel" {
+  name = "foobar-test-tf"
+  url  = "https://live.stream/master.m3u8"
+}
+
+resource "bpkio_source_asset" "movie" {
+  name = "foobar-test-tf-movie"
+  url  = "https://vod.stream/movie/master.m3u8"
+}
+
+resource "bpkio_service_virtual_channel" "channel" {
+  name = "foobar-test-tf"
+
+  base_live = {
+    id = bpkio_source_live.channel.id
+  }
+}
+
+resource "bpkio_virtual_channel_slot" "movie_night" {
+  service_id = bpkio_service_virtual_channel.channel.id
+  name       = "Movie night"
+  start_time = "2025-06-01T20:00:00Z"
+  end_time   = "2025-06-01T22:00:00Z"
+
+  replacement = {
+    id = bpkio_source_asset.movie.id
+  }
+}
@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package bpkiomock
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// contentReplacementInput is the body of create and update requests.
+type contentReplacementInput struct {
+	Name               string   `json:"name"`
+	Tags               []string `json:"tags"`
+	Source             *ref     `json:"source,omitempty"`
+	Replacement        *ref     `json:"replacement,omitempty"`
+	TranscodingProfile *ref     `json:"transcodingProfile,omitempty"`
+}
+
+// contentReplacement is a stored content replacement service.
+type contentReplacement struct {
+	ID           int64
+	Input        contentReplacementInput
+	URL          string
+	State        string
+	CreationDate string
+	UpdateDate   string
+}
+
+func (s *Server) registerContentReplacements(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/services/content-replacement", s.createContentReplacement)
+	mux.HandleFunc("GET /v1/services/content-replacement/{id}", s.getContentReplacement)
+	mux.HandleFunc("PUT /v1/services/content-replacement/{id}", s.updateContentReplacement)
+	mux.HandleFunc("DELETE /v1/services/content-replacement/{id}", s.deleteContentReplacement)
+}
+
+// checkContentReplacement validates input and its references, see
+// checkAdInsertion. The caller holds s.mu.
+func (s *Server) checkContentReplacement(w http.ResponseWriter, input *contentReplacementInput) bool {
+	messages := validateName(input.Name)
+	if input.Source == nil {
+		messages = append(messages, "source should not be empty")
+	}
+	if input.Replacement == nil {
+		messages = append(messages, "replacement should not be empty")
+	}
+	if len(messages) > 0 {
+		writeValidationError(w, messages...)
+		return false
+	}
+
+	forbidden := func(field string, id int64) bool {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("Access to %s %d is not allowed", field, id))
+		return false
+	}
+
+	if !s.isSource(input.Source, "live") {
+		return forbidden("source", input.Source.ID)
+	}
+	if !s.isSource(input.Replacement, "slate", "asset") {
+		return forbidden("replacement", input.Replacement.ID)
+	}
+	if input.TranscodingProfile != nil {
+		if _, ok := s.transcodingProfiles[input.TranscodingProfile.ID]; !ok {
+			return forbidden("transcodingProfile", input.TranscodingProfile.ID)
+		}
+	}
+	return true
+}
+
+// renderContentReplacement returns the API representation of svc. The caller
+// holds s.mu.
+func (s *Server) renderContentReplacement(svc *contentReplacement) map[string]any {
+	out := serviceSummary(svc.ID, "content-replacement", svc.Input.Name, svc.URL, svc.State, svc.CreationDate, svc.UpdateDate, svc.Input.Tags)
+	out["source"] = s.expand(svc.Input.Source)
+	out["replacement"] = s.expand(svc.Input.Replacement)
+	out["transcodingProfile"] = s.expand(svc.Input.TranscodingProfile)
+	return out
+}
+
+func (s *Server) createContentReplacement(w http.ResponseWriter, r *http.Request) {
+	var input contentReplacementInput
+	if !decode(w, r, &input) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkContentReplacement(w, &input) {
+		return
+	}
+
+	id := s.newID()
+	svc := &contentReplacement{
+		ID:           id,
+		Input:        input,
+		URL:          fmt.Sprintf("https://stream.broadpeak.io/%032x/", id),
+		State:        "enabled",
+		CreationDate: now(),
+	}
+	svc.UpdateDate = svc.CreationDate
+	s.contentReplacements[id] = svc
+	writeJSON(w, http.StatusCreated, s.renderContentReplacement(svc))
+}
+
+func (s *Server) getContentReplacement(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc, ok := s.contentReplacements[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderContentReplacement(svc))
+}
+
+func (s *Server) updateContentReplacement(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var input contentReplacementInput
+	if !decode(w, r, &input) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc, ok := s.contentReplacements[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	if !s.checkContentReplacement(w, &input) {
+		return
+	}
+
+	svc.Input = input
+	svc.UpdateDate = now()
+	writeJSON(w, http.StatusOK, s.renderContentReplacement(svc))
+}
+
+func (s *Server) deleteContentReplacement(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.contentReplacements[id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	delete(s.contentReplacements, id)
+	writeJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("Service %d deleted", id)})
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package bpkiomock provides an in-process HTTP server emulating the subset of
+// the Broadpeak REST API used by the provider, so that acceptance tests can run
+// without network access or a tenant.
+//
+// The server keeps its objects in memory, allocates realistic IDs, answers
+// unknown IDs with 404s and rejects invalid input the same way the API does.
+// Tests can also make it fail on purpose (see Server.InjectFailure).
+package bpkiomock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// firstID is the first ID handed out by the server; real tenants have IDs in
+// the same range.
+const firstID = 100001
+
+// maxNameLength is the longest name accepted by the API.
+const maxNameLength = 100
+
+// Failure describes requests that the server must reject instead of serving.
+type Failure struct {
+	// Method and PathPrefix select the requests to fail; empty values match
+	// any request.
+	Method     string
+	PathPrefix string
+
+	// Status is the HTTP status code of the injected response.
+	Status int
+
+	// RetryAfter, when set, is sent as the Retry-After header.
+	RetryAfter string
+
+	// Count is the number of matching requests to fail.
+	Count int
+}
+
+// Server is a mock Broadpeak API. Create one with NewServer and point the
+// provider endpoint at its URL.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	nextID   int64
+	failures []*Failure
+	requests []string
+
+	sources             map[int64]*source
+	adInsertions        map[int64]*adInsertion
+	contentReplacements map[int64]*contentReplacement
+	virtualChannels     map[int64]*virtualChannel
+	slots               map[int64]*slot
+	transcodingProfiles map[int64]*transcodingProfile
+}
+
+// NewServer starts a mock Broadpeak API seeded with one transcoding profile.
+// Callers must Close it.
+func NewServer() *Server {
+	s := &Server{
+		nextID:              firstID,
+		sources:             map[int64]*source{},
+		adInsertions:        map[int64]*adInsertion{},
+		contentReplacements: map[int64]*contentReplacement{},
+		virtualChannels:     map[int64]*virtualChannel{},
+		slots:               map[int64]*slot{},
+		transcodingProfiles: map[int64]*transcodingProfile{},
+	}
+	s.AddTranscodingProfile("bpkio-default", `{"packaging":{"--hls-client-manifest-version=":"4"},"servicetype":"offline_transcoding","transcoding":{"jobs":[],"common":{}}}`)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/tenants/me", s.getTenant)
+	s.registerSources(mux)
+	s.registerServices(mux)
+	s.registerContentReplacements(mux)
+	s.registerVirtualChannels(mux)
+	s.registerSlots(mux)
+	s.registerTranscodingProfiles(mux)
+
+	s.Server = httptest.NewServer(s.middleware(mux))
+	return s
+}
+
+// InjectFailure makes the next f.Count requests matching f fail.
+func (s *Server) InjectFailure(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &f)
+}
+
+// Requests returns the requests served so far, as "METHOD /path" strings.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// middleware records requests, checks that they are authenticated and serves
+// the injected failures.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		var failure *Failure
+		for _, f := range s.failures {
+			if f.Count > 0 &&
+				(f.Method == "" || f.Method == r.Method) &&
+				strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+				f.Count--
+				failure = f
+				break
+			}
+		}
+		s.mu.Unlock()
+
+		if failure != nil {
+			if failure.RetryAfter != "" {
+				w.Header().Set("Retry-After", failure.RetryAfter)
+			}
+			writeError(w, failure.Status, "Injected failure")
+			return
+		}
+
+		if r.Header.Get("Authorization") == "" && r.Header.Get("X-Api-Key") == "" {
+			writeError(w, http.StatusUnauthorized, "Missing API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) getTenant(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"id": 1, "name": "mock-tenant"})
+}
+
+// newID allocates an object ID. The caller holds s.mu.
+func (s *Server) newID() int64 {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// errorBody is the error payload of the API: a single message for most
+// errors, a list of them for validation errors.
+type errorBody struct {
+	StatusCode int    `json:"statusCode"`
+	Message    any    `json:"message"`
+	Error      string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorBody{StatusCode: status, Message: message, Error: http.StatusText(status)})
+}
+
+func writeValidationError(w http.ResponseWriter, messages ...string) {
+	writeJSON(w, http.StatusBadRequest, errorBody{StatusCode: http.StatusBadRequest, Message: messages, Error: http.StatusText(http.StatusBadRequest)})
+}
+
+// decode reads the JSON request body into v, answering 400 when it is not
+// valid.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeValidationError(w, fmt.Sprintf("invalid JSON body: %s", err))
+		return false
+	}
+	return true
+}
+
+// pathID parses the {id} path value, answering 404 when it is not a number.
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%q is not a valid ID", r.PathValue("id")))
+		return 0, false
+	}
+	return id, true
+}
+
+// pagination returns the offset and limit query parameters of a listing.
+func pagination(r *http.Request) (int, int) {
+	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	return offset, limit
+}
+
+// window returns the [offset, offset+limit) window of items.
+func window[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	return items[offset:min(len(items), offset+limit)]
+}
+
+// validateName returns the validation messages of an object name.
+func validateName(name string) []string {
+	switch {
+	case name == "":
+		return []string{"name should not be empty"}
+	case len(name) > maxNameLength:
+		return []string{fmt.Sprintf("name must be shorter than or equal to %d characters", maxNameLength)}
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package bpkiomock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// call sends an authenticated request to srv and decodes the JSON answer.
+func call(t *testing.T, srv *Server, method, path, body string) (int, map[string]any) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, srv.URL+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	out := map[string]any{}
+	if strings.HasPrefix(string(raw), "{") {
+		require.NoError(t, json.Unmarshal(raw, &out))
+	}
+	return res.StatusCode, out
+}
+
+func TestServer_Sources(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	status, live := call(t, srv, http.MethodPost, "/v1/sources/live", `{"name":"live","url":"https://origin.example.com/index.m3u8"}`)
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, "live", live["type"])
+	require.Equal(t, "HLS", live["format"])
+	id := int64(live["id"].(float64))
+	require.Greater(t, id, int64(firstID))
+
+	status, _ = call(t, srv, http.MethodPost, "/v1/sources/live", `{"name":"live","url":"https://origin.example.com/other.m3u8"}`)
+	require.Equal(t, http.StatusForbidden, status, "duplicate names are refused")
+
+	status, body := call(t, srv, http.MethodPost, "/v1/sources/slate", `{"name":"slate","url":"https://does-not-exist.example.com/slate.jpg"}`)
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, []any{"url https://does-not-exist.example.com/slate.jpg is unreachable"}, body["message"])
+
+	status, body = call(t, srv, http.MethodPost, "/v1/sources/ad-server", fmt.Sprintf(`{"name":%q,"url":"https://ads.example.com"}`, strings.Repeat("x", 101)))
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, "Bad Request", body["error"])
+
+	status, _ = call(t, srv, http.MethodPut, fmt.Sprintf("/v1/sources/live/%d", id), `{"name":"renamed","url":"https://origin.example.com/index.mpd"}`)
+	require.Equal(t, http.StatusOK, status)
+
+	status, live = call(t, srv, http.MethodGet, fmt.Sprintf("/v1/sources/live/%d", id), "")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "renamed", live["name"])
+	require.Equal(t, "DASH", live["format"])
+
+	status, _ = call(t, srv, http.MethodGet, fmt.Sprintf("/v1/sources/slate/%d", id), "")
+	require.Equal(t, http.StatusNotFound, status, "sources are only served under their own type")
+
+	status, asset := call(t, srv, http.MethodPost, "/v1/sources/asset", `{"name":"asset","url":"https://origin.example.com/movie.mp4"}`)
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, "MP4", asset["format"])
+
+	status, _ = call(t, srv, http.MethodPost, "/v1/sources/asset-catalog", `{"name":"catalog","url":"https://origin.example.com/vod/"}`)
+	require.Equal(t, http.StatusBadRequest, status, "asset catalogs need an asset sample")
+
+	status, _ = call(t, srv, http.MethodDelete, fmt.Sprintf("/v1/sources/live/%d", id), "")
+	require.Equal(t, http.StatusOK, status)
+	status, _ = call(t, srv, http.MethodGet, fmt.Sprintf("/v1/sources/live/%d", id), "")
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestServer_AdInsertion(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, live := call(t, srv, http.MethodPost, "/v1/sources/live", `{"name":"live","url":"https://origin.example.com/index.m3u8"}`)
+	_, slate := call(t, srv, http.MethodPost, "/v1/sources/slate", `{"name":"slate","url":"https://origin.example.com/slate.jpg"}`)
+	_, adServer := call(t, srv, http.MethodPost, "/v1/sources/ad-server", `{"name":"ads","url":"https://ads.example.com/vast"}`)
+
+	status, _ := call(t, srv, http.MethodPost, "/v1/services/ad-insertion", `{"name":"svc","source":{"id":999999999}}`)
+	require.Equal(t, http.StatusForbidden, status)
+
+	status, _ = call(t, srv, http.MethodPost, "/v1/services/ad-insertion", fmt.Sprintf(
+		`{"name":"svc","source":{"id":%v},"liveAdReplacement":{"adServer":{"id":%v},"gapFiller":{"id":%v}}}`,
+		live["id"], adServer["id"], live["id"]))
+	require.Equal(t, http.StatusForbidden, status, "the gap filler must be a slate")
+
+	status, svc := call(t, srv, http.MethodPost, "/v1/services/ad-insertion", fmt.Sprintf(
+		`{"name":"svc","source":{"id":%v},"liveAdReplacement":{"adServer":{"id":%v},"gapFiller":{"id":%v},"spotAware":{"mode":"disabled"}}}`,
+		live["id"], adServer["id"], slate["id"]))
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, "ad-insertion", svc["type"])
+	require.Equal(t, "live", svc["source"].(map[string]any)["name"])
+	require.Equal(t, "slate", svc["liveAdReplacement"].(map[string]any)["gapFiller"].(map[string]any)["name"])
+	require.Equal(t, "enabled", svc["state"])
+
+	status, bypassed := call(t, srv, http.MethodPut, fmt.Sprintf("/v1/services/ad-insertion/%v/bypass", svc["id"]), "")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "bypassed", bypassed["state"])
+	status, _ = call(t, srv, http.MethodPut, fmt.Sprintf("/v1/services/ad-insertion/%v/stop", svc["id"]), "")
+	require.Equal(t, http.StatusNotFound, status)
+
+	status, _ = call(t, srv, http.MethodPut, fmt.Sprintf("/v1/services/ad-insertion/%v", svc["id"]), fmt.Sprintf(
+		`{"name":"svc","source":{"id":%v},"vodAdInsertion":{"adServer":{"id":%v}}}`, live["id"], adServer["id"]))
+	require.Equal(t, http.StatusBadRequest, status, "VOD ad insertion needs a VOD source")
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/v1/services?offset=0&limit=10", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer test")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	var services []map[string]any
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&services))
+	require.Len(t, services, 1)
+	require.Equal(t, svc["id"], services[0]["id"])
+}
+
+func TestServer_ContentReplacement(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, live := call(t, srv, http.MethodPost, "/v1/sources/live", `{"name":"live","url":"https://origin.example.com/index.m3u8"}`)
+	_, slate := call(t, srv, http.MethodPost, "/v1/sources/slate", `{"name":"slate","url":"https://origin.example.com/slate.jpg"}`)
+
+	status, _ := call(t, srv, http.MethodPost, "/v1/services/content-replacement", fmt.Sprintf(
+		`{"name":"svc","source":{"id":%v},"replacement":{"id":%v}}`, live["id"], live["id"]))
+	require.Equal(t, http.StatusForbidden, status, "the replacement must be a slate or an asset")
+
+	status, svc := call(t, srv, http.MethodPost, "/v1/services/content-replacement", fmt.Sprintf(
+		`{"name":"svc","source":{"id":%v},"replacement":{"id":%v}}`, live["id"], slate["id"]))
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, "content-replacement", svc["type"])
+	require.Equal(t, "slate", svc["replacement"].(map[string]any)["name"])
+
+	slateURL := fmt.Sprintf("/v1/sources/slate/%v", slate["id"])
+	status, _ = call(t, srv, http.MethodDelete, slateURL, "")
+	require.Equal(t, http.StatusConflict, status, "the slate is the replacement of the service")
+
+	id := int64(svc["id"].(float64))
+	status, _ = call(t, srv, http.MethodDelete, fmt.Sprintf("/v1/services/content-replacement/%d", id), "")
+	require.Equal(t, http.StatusOK, status)
+	status, _ = call(t, srv, http.MethodGet, fmt.Sprintf("/v1/services/content-replacement/%d", id), "")
+	require.Equal(t, http.StatusNotFound, status)
+	status, _ = call(t, srv, http.MethodDelete, slateURL, "")
+	require.Equal(t, http.StatusOK, status)
+}
+
+func TestServer_VirtualChannel(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, live := call(t, srv, http.MethodPost, "/v1/sources/live", `{"name":"live","url":"https://origin.example.com/index.m3u8"}`)
+	_, slate := call(t, srv, http.MethodPost, "/v1/sources/slate", `{"name":"slate","url":"https://origin.example.com/slate.jpg"}`)
+
+	status, _ := call(t, srv, http.MethodPost, "/v1/services/virtual-channel", fmt.Sprintf(
+		`{"name":"vc","baseLive":{"id":%v},"adBreakInsertion":{"gapFiller":{"id":%v}}}`, slate["id"], slate["id"]))
+	require.Equal(t, http.StatusForbidden, status, "the base live must be a live source")
+
+	status, svc := call(t, srv, http.MethodPost, "/v1/services/virtual-channel", fmt.Sprintf(
+		`{"name":"vc","baseLive":{"id":%v},"adBreakInsertion":{"gapFiller":{"id":%v}}}`, live["id"], slate["id"]))
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, "virtual-channel", svc["type"])
+	require.Equal(t, "slate", svc["adBreakInsertion"].(map[string]any)["gapFiller"].(map[string]any)["name"])
+
+	id := int64(svc["id"].(float64))
+	status, _ = call(t, srv, http.MethodDelete, fmt.Sprintf("/v1/services/virtual-channel/%d", id), "")
+	require.Equal(t, http.StatusOK, status)
+}
+
+func TestServer_Slots(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, live := call(t, srv, http.MethodPost, "/v1/sources/live", `{"name":"live","url":"https://origin.example.com/index.m3u8"}`)
+	_, slate := call(t, srv, http.MethodPost, "/v1/sources/slate", `{"name":"slate","url":"https://origin.example.com/slate.jpg"}`)
+	_, svc := call(t, srv, http.MethodPost, "/v1/services/content-replacement", fmt.Sprintf(
+		`{"name":"svc","source":{"id":%v},"replacement":{"id":%v}}`, live["id"], slate["id"]))
+	slots := fmt.Sprintf("/v1/services/content-replacement/%v/slots", svc["id"])
+
+	status, _ := call(t, srv, http.MethodPost, "/v1/services/virtual-channel/"+fmt.Sprint(svc["id"])+"/slots", "{}")
+	require.Equal(t, http.StatusNotFound, status, "the service is not a virtual channel")
+
+	status, _ = call(t, srv, http.MethodPost, slots, fmt.Sprintf(
+		`{"startTime":"2030-01-01T20:00:00Z","duration":600,"replacement":{"id":%v}}`, live["id"]))
+	require.Equal(t, http.StatusForbidden, status, "the replacement must be a slate or an asset")
+
+	status, slot := call(t, srv, http.MethodPost, slots, fmt.Sprintf(
+		`{"startTime":"2030-01-01T22:00:00+02:00","duration":600,"replacement":{"id":%v}}`, slate["id"]))
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, "2030-01-01T20:00:00Z", slot["startTime"])
+	require.Equal(t, "2030-01-01T20:10:00Z", slot["endTime"])
+
+	status, past := call(t, srv, http.MethodPost, slots, fmt.Sprintf(
+		`{"startTime":"2020-01-01T20:00:00Z","endTime":"2020-01-01T21:00:00Z","replacement":{"id":%v}}`, slate["id"]))
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, float64(3600), past["duration"])
+	status, _ = call(t, srv, http.MethodDelete, fmt.Sprintf("%s/%v", slots, past["id"]), "")
+	require.Equal(t, http.StatusBadRequest, status, "slots in the past cannot be deleted")
+
+	status, _ = call(t, srv, http.MethodDelete, fmt.Sprintf("%s/%v", slots, slot["id"]), "")
+	require.Equal(t, http.StatusOK, status)
+	status, _ = call(t, srv, http.MethodGet, fmt.Sprintf("%s/%v", slots, slot["id"]), "")
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestServer_TranscodingProfiles(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	status, profile := call(t, srv, http.MethodPost, "/v1/transcoding-profiles", `{"name":"profile","content":"{\"jobs\":[]}"}`)
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, `{"jobs":[]}`, profile["content"])
+	require.NotEmpty(t, profile["internalId"])
+	path := fmt.Sprintf("/v1/transcoding-profiles/%v", profile["id"])
+
+	status, body := call(t, srv, http.MethodPut, path, `{"name":"profile","content":"{jobs"}`)
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, []any{"content must be a valid JSON string"}, body["message"])
+
+	status, profile = call(t, srv, http.MethodPut, path, `{"name":"renamed","content":"{}"}`)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "renamed", profile["name"])
+
+	status, _ = call(t, srv, http.MethodDelete, path, "")
+	require.Equal(t, http.StatusOK, status)
+	status, _ = call(t, srv, http.MethodGet, path, "")
+	require.Equal(t, http.StatusNotFound, status)
+}
+
+func TestServer_InjectFailure(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.InjectFailure(Failure{Method: http.MethodPost, PathPrefix: "/v1/sources/", Status: http.StatusTooManyRequests, RetryAfter: "0", Count: 2})
+
+	for i := 0; i < 2; i++ {
+		status, _ := call(t, srv, http.MethodPost, "/v1/sources/slate", `{"name":"slate","url":"https://origin.example.com/slate.jpg"}`)
+		require.Equal(t, http.StatusTooManyRequests, status)
+	}
+	status, _ := call(t, srv, http.MethodPost, "/v1/sources/slate", `{"name":"slate","url":"https://origin.example.com/slate.jpg"}`)
+	require.Equal(t, http.StatusCreated, status)
+	require.Len(t, srv.Requests(), 3)
+}
+
+func TestServer_Unauthenticated(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/v1/tenants/me")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
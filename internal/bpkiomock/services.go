@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package bpkiomock
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+)
+
+// ref is a reference to another object by ID.
+type ref struct {
+	ID int64 `json:"id"`
+}
+
+type serverSideAdTracking struct {
+	Enable                          bool `json:"enable"`
+	CheckAdMediaSegmentAvailability bool `json:"checkAdMediaSegmentAvailability"`
+}
+
+type advancedOptions struct {
+	AuthorizationHeader *customHeader `json:"authorizationHeader,omitempty"`
+}
+
+type liveAdPreRoll struct {
+	AdServer    *ref  `json:"adServer,omitempty"`
+	MaxDuration int64 `json:"maxDuration"`
+	Offset      int64 `json:"offset"`
+}
+
+type spotAware struct {
+	Mode string `json:"mode"`
+}
+
+type liveAdReplacement struct {
+	AdServer  *ref       `json:"adServer,omitempty"`
+	GapFiller *ref       `json:"gapFiller,omitempty"`
+	SpotAware *spotAware `json:"spotAware,omitempty"`
+}
+
+type vodAdInsertion struct {
+	AdServer *ref `json:"adServer,omitempty"`
+}
+
+// adInsertionInput is the body of create and update requests.
+type adInsertionInput struct {
+	Name                 string                `json:"name"`
+	Tags                 []string              `json:"tags"`
+	Source               *ref                  `json:"source,omitempty"`
+	TranscodingProfile   *ref                  `json:"transcodingProfile,omitempty"`
+	EnableAdTranscoding  bool                  `json:"enableAdTranscoding"`
+	ServerSideAdTracking *serverSideAdTracking `json:"serverSideAdTracking,omitempty"`
+	AdvancedOptions      *advancedOptions      `json:"advancedOptions,omitempty"`
+	LiveAdPreRoll        *liveAdPreRoll        `json:"liveAdPreRoll,omitempty"`
+	LiveAdReplacement    *liveAdReplacement    `json:"liveAdReplacement,omitempty"`
+	VodAdInsertion       *vodAdInsertion       `json:"vodAdInsertion,omitempty"`
+}
+
+// adInsertion is a stored ad insertion service. References are kept as IDs
+// and expanded when the service is rendered, like the API does.
+type adInsertion struct {
+	ID           int64
+	Input        adInsertionInput
+	URL          string
+	State        string
+	CreationDate string
+	UpdateDate   string
+}
+
+func (s *Server) registerServices(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/services", s.listServices)
+	mux.HandleFunc("POST /v1/services/ad-insertion", s.createAdInsertion)
+	mux.HandleFunc("GET /v1/services/ad-insertion/{id}", s.getAdInsertion)
+	mux.HandleFunc("PUT /v1/services/ad-insertion/{id}", s.updateAdInsertion)
+	mux.HandleFunc("DELETE /v1/services/ad-insertion/{id}", s.deleteAdInsertion)
+	mux.HandleFunc("PUT /v1/services/ad-insertion/{id}/{action}", s.setAdInsertionState)
+}
+
+// serviceStates maps the state-change calls of a service onto the state
+// they switch it to.
+var serviceStates = map[string]string{
+	"enable": "enabled",
+	"pause":  "paused",
+	"bypass": "bypassed",
+}
+
+// serviceSummary is the listing entry of any service.
+func serviceSummary(id int64, serviceType, name, url, state, creationDate, updateDate string, tags []string) map[string]any {
+	return map[string]any{
+		"id":           id,
+		"type":         serviceType,
+		"name":         name,
+		"url":          url,
+		"state":        state,
+		"creationDate": creationDate,
+		"updateDate":   updateDate,
+		"tags":         tags,
+	}
+}
+
+func (s *Server) listServices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]map[string]any, 0, len(s.adInsertions)+len(s.contentReplacements)+len(s.virtualChannels))
+	for _, svc := range s.adInsertions {
+		all = append(all, serviceSummary(svc.ID, "ad-insertion", svc.Input.Name, svc.URL, svc.State, svc.CreationDate, svc.UpdateDate, svc.Input.Tags))
+	}
+	for _, svc := range s.contentReplacements {
+		all = append(all, serviceSummary(svc.ID, "content-replacement", svc.Input.Name, svc.URL, svc.State, svc.CreationDate, svc.UpdateDate, svc.Input.Tags))
+	}
+	for _, svc := range s.virtualChannels {
+		all = append(all, serviceSummary(svc.ID, "virtual-channel", svc.Input.Name, svc.URL, svc.State, svc.CreationDate, svc.UpdateDate, svc.Input.Tags))
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i]["id"].(int64) < all[j]["id"].(int64) })
+
+	offset, limit := pagination(r)
+	writeJSON(w, http.StatusOK, window(all, offset, limit))
+}
+
+// checkAdInsertion validates input and its references. Like the API, it
+// answers 400 for invalid values and 403 for references to objects that do
+// not exist on the tenant. The caller holds s.mu.
+func (s *Server) checkAdInsertion(w http.ResponseWriter, input *adInsertionInput) bool {
+	if messages := validateName(input.Name); len(messages) > 0 {
+		writeValidationError(w, messages...)
+		return false
+	}
+
+	forbidden := func(field string, id int64) bool {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("Access to %s %d is not allowed", field, id))
+		return false
+	}
+
+	if input.Source != nil && !s.isSource(input.Source, "live", "asset", "asset-catalog") {
+		return forbidden("source", input.Source.ID)
+	}
+	if input.VodAdInsertion != nil && input.Source != nil && s.isSource(input.Source, "live") {
+		writeValidationError(w, "vodAdInsertion is not supported with a live source")
+		return false
+	}
+	if input.TranscodingProfile != nil {
+		if _, ok := s.transcodingProfiles[input.TranscodingProfile.ID]; !ok {
+			return forbidden("transcodingProfile", input.TranscodingProfile.ID)
+		}
+	}
+	if p := input.LiveAdPreRoll; p != nil && p.AdServer != nil && !s.isSource(p.AdServer, "ad-server") {
+		return forbidden("liveAdPreRoll.adServer", p.AdServer.ID)
+	}
+	if p := input.LiveAdReplacement; p != nil {
+		if p.AdServer != nil && !s.isSource(p.AdServer, "ad-server") {
+			return forbidden("liveAdReplacement.adServer", p.AdServer.ID)
+		}
+		if p.GapFiller != nil && !s.isSource(p.GapFiller, "slate") {
+			return forbidden("liveAdReplacement.gapFiller", p.GapFiller.ID)
+		}
+	}
+	if p := input.VodAdInsertion; p != nil && p.AdServer != nil && !s.isSource(p.AdServer, "ad-server") {
+		return forbidden("vodAdInsertion.adServer", p.AdServer.ID)
+	}
+	return true
+}
+
+// isSource reports whether r references a source of one of the given types.
+// The caller holds s.mu.
+func (s *Server) isSource(r *ref, sourceTypes ...string) bool {
+	src, ok := s.sources[r.ID]
+	return ok && slices.Contains(sourceTypes, src.Type)
+}
+
+// expand returns the object referenced by r as rendered by the API. The
+// caller holds s.mu.
+func (s *Server) expand(r *ref) any {
+	if r == nil {
+		return nil
+	}
+	if src, ok := s.sources[r.ID]; ok {
+		return src
+	}
+	if profile, ok := s.transcodingProfiles[r.ID]; ok {
+		return profile
+	}
+	return r
+}
+
+// renderAdInsertion returns the API representation of svc. The caller holds
+// s.mu.
+func (s *Server) renderAdInsertion(svc *adInsertion) map[string]any {
+	out := serviceSummary(svc.ID, "ad-insertion", svc.Input.Name, svc.URL, svc.State, svc.CreationDate, svc.UpdateDate, svc.Input.Tags)
+	out["enableAdTranscoding"] = svc.Input.EnableAdTranscoding
+	out["source"] = s.expand(svc.Input.Source)
+	out["transcodingProfile"] = s.expand(svc.Input.TranscodingProfile)
+	out["serverSideAdTracking"] = svc.Input.ServerSideAdTracking
+	out["advancedOptions"] = svc.Input.AdvancedOptions
+
+	if p := svc.Input.LiveAdPreRoll; p != nil {
+		out["liveAdPreRoll"] = map[string]any{
+			"adServer":    s.expand(p.AdServer),
+			"maxDuration": p.MaxDuration,
+			"offset":      p.Offset,
+		}
+	}
+	if p := svc.Input.LiveAdReplacement; p != nil {
+		out["liveAdReplacement"] = map[string]any{
+			"adServer":  s.expand(p.AdServer),
+			"gapFiller": s.expand(p.GapFiller),
+			"spotAware": p.SpotAware,
+		}
+	}
+	if p := svc.Input.VodAdInsertion; p != nil {
+		out["vodAdInsertion"] = map[string]any{
+			"adServer": s.expand(p.AdServer),
+		}
+	}
+	return out
+}
+
+func (s *Server) createAdInsertion(w http.ResponseWriter, r *http.Request) {
+	var input adInsertionInput
+	if !decode(w, r, &input) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkAdInsertion(w, &input) {
+		return
+	}
+
+	id := s.newID()
+	svc := &adInsertion{
+		ID:           id,
+		Input:        input,
+		URL:          fmt.Sprintf("https://stream.broadpeak.io/%032x/", id),
+		State:        "enabled",
+		CreationDate: now(),
+	}
+	svc.UpdateDate = svc.CreationDate
+	s.adInsertions[id] = svc
+	writeJSON(w, http.StatusCreated, s.renderAdInsertion(svc))
+}
+
+func (s *Server) getAdInsertion(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc, ok := s.adInsertions[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderAdInsertion(svc))
+}
+
+func (s *Server) updateAdInsertion(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var input adInsertionInput
+	if !decode(w, r, &input) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc, ok := s.adInsertions[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	if !s.checkAdInsertion(w, &input) {
+		return
+	}
+
+	svc.Input = input
+	svc.UpdateDate = now()
+	writeJSON(w, http.StatusOK, s.renderAdInsertion(svc))
+}
+
+func (s *Server) deleteAdInsertion(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.adInsertions[id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	delete(s.adInsertions, id)
+	writeJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("Service %d deleted", id)})
+}
+
+func (s *Server) setAdInsertionState(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	state, ok := serviceStates[r.PathValue("action")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cannot PUT %s", r.URL.Path))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc, ok := s.adInsertions[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	svc.State = state
+	svc.UpdateDate = now()
+	writeJSON(w, http.StatusOK, s.renderAdInsertion(svc))
+}
@@ -0,0 +1,261 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package bpkiomock
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// slotInput is the body of slot create and update requests.
+type slotInput struct {
+	Name        string `json:"name"`
+	StartTime   string `json:"startTime"`
+	EndTime     string `json:"endTime"`
+	Duration    int64  `json:"duration"`
+	Replacement *ref   `json:"replacement,omitempty"`
+	Category    *ref   `json:"category,omitempty"`
+}
+
+// slot is a stored slot of a virtual channel or content replacement service.
+// Start and end are resolved from the input when it is stored.
+type slot struct {
+	ID          int64
+	ServiceType string
+	ServiceID   int64
+	Input       slotInput
+	Start       time.Time
+	End         time.Time
+}
+
+// slotReplacementTypes lists the types of source that the slots of each type
+// of service can play.
+var slotReplacementTypes = map[string][]string{
+	"virtual-channel":     {"live", "asset", "slate"},
+	"content-replacement": {"slate", "asset"},
+}
+
+func (s *Server) registerSlots(mux *http.ServeMux) {
+	for serviceType := range slotReplacementTypes {
+		prefix := "/v1/services/" + serviceType + "/{serviceId}/slots"
+		mux.HandleFunc("GET "+prefix, s.listSlots(serviceType))
+		mux.HandleFunc("POST "+prefix, s.createSlot(serviceType))
+		mux.HandleFunc("GET "+prefix+"/{id}", s.getSlot(serviceType))
+		mux.HandleFunc("PUT "+prefix+"/{id}", s.updateSlot(serviceType))
+		mux.HandleFunc("DELETE "+prefix+"/{id}", s.deleteSlot(serviceType))
+	}
+}
+
+// hasService reports whether a service of the given type exists. The caller
+// holds s.mu.
+func (s *Server) hasService(serviceType string, id int64) bool {
+	switch serviceType {
+	case "virtual-channel":
+		_, ok := s.virtualChannels[id]
+		return ok
+	case "content-replacement":
+		_, ok := s.contentReplacements[id]
+		return ok
+	}
+	return false
+}
+
+// serviceID parses the {serviceId} path value and checks that the service
+// exists, answering 404 otherwise. The caller holds s.mu.
+func (s *Server) serviceID(w http.ResponseWriter, r *http.Request, serviceType string) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("serviceId"), 10, 64)
+	if err != nil || !s.hasService(serviceType, id) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %s not found", r.PathValue("serviceId")))
+		return 0, false
+	}
+	return id, true
+}
+
+// lookupSlot returns the slot of the request, answering 404 when it does not
+// exist on the service. The caller holds s.mu.
+func (s *Server) lookupSlot(w http.ResponseWriter, r *http.Request, serviceType string) (*slot, bool) {
+	serviceID, ok := s.serviceID(w, r, serviceType)
+	if !ok {
+		return nil, false
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return nil, false
+	}
+	sl, ok := s.slots[id]
+	if !ok || sl.ServiceType != serviceType || sl.ServiceID != serviceID {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Slot %d not found", id))
+		return nil, false
+	}
+	return sl, true
+}
+
+// checkSlot validates input and resolves its start and end into sl, see
+// checkAdInsertion. The caller holds s.mu.
+func (s *Server) checkSlot(w http.ResponseWriter, serviceType string, input *slotInput, sl *slot) bool {
+	var messages []string
+	start, err := time.Parse(time.RFC3339, input.StartTime)
+	if err != nil {
+		messages = append(messages, "startTime must be a valid ISO 8601 date string")
+	}
+	end := start.Add(time.Duration(input.Duration) * time.Second)
+	switch {
+	case input.EndTime != "":
+		if end, err = time.Parse(time.RFC3339, input.EndTime); err != nil || !end.After(start) {
+			messages = append(messages, "endTime must be after startTime")
+		}
+	case input.Duration <= 0:
+		messages = append(messages, "either endTime or duration must be provided")
+	}
+	if input.Replacement == nil {
+		messages = append(messages, "replacement should not be empty")
+	}
+	if len(messages) > 0 {
+		writeValidationError(w, messages...)
+		return false
+	}
+
+	if !s.isSource(input.Replacement, slotReplacementTypes[serviceType]...) {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("Access to replacement %d is not allowed", input.Replacement.ID))
+		return false
+	}
+
+	sl.Input = *input
+	sl.Start = start.UTC()
+	sl.End = end.UTC()
+	return true
+}
+
+// renderSlot returns the API representation of sl. The caller holds s.mu.
+func (s *Server) renderSlot(sl *slot) map[string]any {
+	out := map[string]any{
+		"id":          sl.ID,
+		"name":        sl.Input.Name,
+		"startTime":   sl.Start.Format(time.RFC3339),
+		"endTime":     sl.End.Format(time.RFC3339),
+		"duration":    int64(sl.End.Sub(sl.Start) / time.Second),
+		"replacement": s.expand(sl.Input.Replacement),
+	}
+	if sl.Input.Category != nil {
+		out["category"] = map[string]any{"id": sl.Input.Category.ID, "name": fmt.Sprintf("category-%d", sl.Input.Category.ID)}
+	}
+	return out
+}
+
+// listSlots lists the slots of a service that start between the from and to
+// query parameters, ordered by start time.
+func (s *Server) listSlots(serviceType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, errFrom := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+		to, errTo := time.Parse(time.RFC3339, r.URL.Query().Get("to"))
+		if errFrom != nil || errTo != nil {
+			writeValidationError(w, "from and to must be valid ISO 8601 date strings")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		serviceID, ok := s.serviceID(w, r, serviceType)
+		if !ok {
+			return
+		}
+
+		var slots []*slot
+		for _, sl := range s.slots {
+			if sl.ServiceType == serviceType && sl.ServiceID == serviceID && !sl.Start.Before(from) && sl.Start.Before(to) {
+				slots = append(slots, sl)
+			}
+		}
+		sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+
+		all := make([]map[string]any, 0, len(slots))
+		for _, sl := range slots {
+			all = append(all, s.renderSlot(sl))
+		}
+		offset, limit := pagination(r)
+		writeJSON(w, http.StatusOK, window(all, offset, limit))
+	}
+}
+
+func (s *Server) createSlot(serviceType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input slotInput
+		if !decode(w, r, &input) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		serviceID, ok := s.serviceID(w, r, serviceType)
+		if !ok {
+			return
+		}
+		sl := &slot{ServiceType: serviceType, ServiceID: serviceID}
+		if !s.checkSlot(w, serviceType, &input, sl) {
+			return
+		}
+
+		sl.ID = s.newID()
+		s.slots[sl.ID] = sl
+		writeJSON(w, http.StatusCreated, s.renderSlot(sl))
+	}
+}
+
+func (s *Server) getSlot(serviceType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		sl, ok := s.lookupSlot(w, r, serviceType)
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, s.renderSlot(sl))
+	}
+}
+
+func (s *Server) updateSlot(serviceType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input slotInput
+		if !decode(w, r, &input) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		sl, ok := s.lookupSlot(w, r, serviceType)
+		if !ok {
+			return
+		}
+		if !s.checkSlot(w, serviceType, &input, sl) {
+			return
+		}
+		writeJSON(w, http.StatusOK, s.renderSlot(sl))
+	}
+}
+
+// deleteSlot refuses to delete slots that are over.
+func (s *Server) deleteSlot(serviceType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		sl, ok := s.lookupSlot(w, r, serviceType)
+		if !ok {
+			return
+		}
+		if sl.End.Before(time.Now()) {
+			writeValidationError(w, "Slots in the past cannot be deleted")
+			return
+		}
+		delete(s.slots, sl.ID)
+		writeJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("Slot %d deleted", sl.ID)})
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package bpkiomock
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// unreachableHostMarker marks hosts that the mock treats as unreachable: the
+// API probes live, slate and asset URLs on creation and rejects those it
+// cannot fetch.
+const unreachableHostMarker = "does-not-exist"
+
+// sourceTypes lists the source types served under /v1/sources/{type}.
+var sourceTypes = map[string]bool{
+	"live":          true,
+	"slate":         true,
+	"asset":         true,
+	"asset-catalog": true,
+	"origin":        true,
+	"ad-server":     true,
+}
+
+type customHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type origin struct {
+	CustomHeaders []customHeader `json:"customHeaders"`
+}
+
+type queryParameter struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// source is any kind of source; fields that do not apply to a type are left
+// empty.
+type source struct {
+	ID              int64            `json:"id"`
+	Type            string           `json:"type"`
+	Name            string           `json:"name"`
+	Description     string           `json:"description"`
+	URL             string           `json:"url"`
+	Format          string           `json:"format,omitempty"`
+	AssetSample     string           `json:"assetSample,omitempty"`
+	MultiPeriod     bool             `json:"multiPeriod"`
+	Origin          *origin          `json:"origin,omitempty"`
+	Queries         string           `json:"queries,omitempty"`
+	QueryParameters []queryParameter `json:"queryParameters,omitempty"`
+	Template        string           `json:"template,omitempty"`
+}
+
+func (s *Server) registerSources(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/sources", s.listSources)
+	mux.HandleFunc("POST /v1/sources/{type}", s.createSource)
+	mux.HandleFunc("GET /v1/sources/{type}/{id}", s.getSource)
+	mux.HandleFunc("PUT /v1/sources/{type}/{id}", s.updateSource)
+	mux.HandleFunc("DELETE /v1/sources/{type}/{id}", s.deleteSource)
+}
+
+func (s *Server) listSources(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]*source, 0, len(s.sources))
+	for _, src := range s.sources {
+		all = append(all, src)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+
+	offset, limit := pagination(r)
+	writeJSON(w, http.StatusOK, window(all, offset, limit))
+}
+
+// validateSource returns the validation messages of a source of type
+// sourceType, including the API refusing a URL it cannot reach.
+func validateSource(sourceType string, src *source) []string {
+	messages := validateName(src.Name)
+
+	u, err := url.Parse(src.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return append(messages, "url must be a URL address")
+	}
+	if sourceType != "ad-server" && strings.Contains(u.Host, unreachableHostMarker) {
+		messages = append(messages, fmt.Sprintf("url %s is unreachable", src.URL))
+	}
+	if sourceType == "asset-catalog" && src.AssetSample == "" {
+		messages = append(messages, "assetSample should not be empty")
+	}
+	return messages
+}
+
+// sourceFormat mimics the format detected by the API from the source URL.
+func sourceFormat(sourceType, rawURL string) string {
+	switch {
+	case sourceType != "live" && sourceType != "asset":
+		return ""
+	case strings.Contains(rawURL, ".mpd"):
+		return "DASH"
+	case sourceType == "asset" && strings.HasSuffix(rawURL, ".mp4"):
+		return "MP4"
+	default:
+		return "HLS"
+	}
+}
+
+// findSource returns the source with the given ID and type. The caller holds
+// s.mu.
+func (s *Server) findSource(sourceType string, id int64) (*source, bool) {
+	src, ok := s.sources[id]
+	if !ok || src.Type != sourceType {
+		return nil, false
+	}
+	return src, true
+}
+
+func (s *Server) createSource(w http.ResponseWriter, r *http.Request) {
+	sourceType := r.PathValue("type")
+	if !sourceTypes[sourceType] {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cannot POST %s", r.URL.Path))
+		return
+	}
+
+	var src source
+	if !decode(w, r, &src) {
+		return
+	}
+	if messages := validateSource(sourceType, &src); len(messages) > 0 {
+		writeValidationError(w, messages...)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.sources {
+		if existing.Type == sourceType && existing.Name == src.Name {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("A %s source named %q already exists", sourceType, src.Name))
+			return
+		}
+	}
+
+	src.ID = s.newID()
+	src.Type = sourceType
+	src.Format = sourceFormat(sourceType, src.URL)
+	s.sources[src.ID] = &src
+	writeJSON(w, http.StatusCreated, src)
+}
+
+func (s *Server) getSource(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	src, ok := s.findSource(r.PathValue("type"), id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Source %d not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, src)
+}
+
+func (s *Server) updateSource(w http.ResponseWriter, r *http.Request) {
+	sourceType := r.PathValue("type")
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var update source
+	if !decode(w, r, &update) {
+		return
+	}
+	if messages := validateSource(sourceType, &update); len(messages) > 0 {
+		writeValidationError(w, messages...)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.findSource(sourceType, id); !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Source %d not found", id))
+		return
+	}
+
+	update.ID = id
+	update.Type = sourceType
+	update.Format = sourceFormat(sourceType, update.URL)
+	s.sources[id] = &update
+	writeJSON(w, http.StatusOK, update)
+}
+
+func (s *Server) deleteSource(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.findSource(r.PathValue("type"), id); !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Source %d not found", id))
+		return
+	}
+	if s.inUse(id) {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Source %d is used by a service", id))
+		return
+	}
+	delete(s.sources, id)
+	writeJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("Source %d deleted", id)})
+}
+
+// inUse reports whether a service or a slot references the source with the
+// given ID. The caller holds s.mu.
+func (s *Server) inUse(id int64) bool {
+	var refs []*ref
+	for _, service := range s.adInsertions {
+		in := service.Input
+		refs = append(refs, in.Source)
+		if in.LiveAdPreRoll != nil {
+			refs = append(refs, in.LiveAdPreRoll.AdServer)
+		}
+		if in.LiveAdReplacement != nil {
+			refs = append(refs, in.LiveAdReplacement.AdServer, in.LiveAdReplacement.GapFiller)
+		}
+		if in.VodAdInsertion != nil {
+			refs = append(refs, in.VodAdInsertion.AdServer)
+		}
+	}
+	for _, service := range s.contentReplacements {
+		refs = append(refs, service.Input.Source, service.Input.Replacement)
+	}
+	for _, service := range s.virtualChannels {
+		in := service.Input
+		refs = append(refs, in.BaseLive)
+		if in.AdBreakInsertion != nil {
+			refs = append(refs, in.AdBreakInsertion.AdServer, in.AdBreakInsertion.GapFiller)
+		}
+		if in.LivePreRoll != nil {
+			refs = append(refs, in.LivePreRoll.AdServer)
+		}
+	}
+	for _, slot := range s.slots {
+		refs = append(refs, slot.Input.Replacement)
+	}
+
+	for _, r := range refs {
+		if r != nil && r.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package bpkiomock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+type transcodingProfile struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Content    string `json:"content"`
+	InternalID string `json:"internalId"`
+}
+
+func (s *Server) registerTranscodingProfiles(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/transcoding-profiles", s.listTranscodingProfiles)
+	mux.HandleFunc("POST /v1/transcoding-profiles", s.createTranscodingProfile)
+	mux.HandleFunc("GET /v1/transcoding-profiles/{id}", s.getTranscodingProfile)
+	mux.HandleFunc("PUT /v1/transcoding-profiles/{id}", s.updateTranscodingProfile)
+	mux.HandleFunc("DELETE /v1/transcoding-profiles/{id}", s.deleteTranscodingProfile)
+}
+
+// validateTranscodingProfile returns the validation messages of a profile.
+// The content is a JSON document serialized as a string.
+func validateTranscodingProfile(profile *transcodingProfile) []string {
+	messages := validateName(profile.Name)
+	if !json.Valid([]byte(profile.Content)) {
+		messages = append(messages, "content must be a valid JSON string")
+	}
+	return messages
+}
+
+// AddTranscodingProfile seeds a transcoding profile and returns its ID.
+func (s *Server) AddTranscodingProfile(name, content string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	s.transcodingProfiles[id] = &transcodingProfile{
+		ID:         id,
+		Name:       name,
+		Content:    content,
+		InternalID: fmt.Sprintf("tp-%d", id),
+	}
+	return id
+}
+
+func (s *Server) listTranscodingProfiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]*transcodingProfile, 0, len(s.transcodingProfiles))
+	for _, profile := range s.transcodingProfiles {
+		all = append(all, profile)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+
+	offset, limit := pagination(r)
+	writeJSON(w, http.StatusOK, window(all, offset, limit))
+}
+
+func (s *Server) getTranscodingProfile(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.transcodingProfiles[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Transcoding profile %d not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, profile)
+}
+
+func (s *Server) createTranscodingProfile(w http.ResponseWriter, r *http.Request) {
+	var profile transcodingProfile
+	if !decode(w, r, &profile) {
+		return
+	}
+	if messages := validateTranscodingProfile(&profile); len(messages) > 0 {
+		writeValidationError(w, messages...)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile.ID = s.newID()
+	profile.InternalID = fmt.Sprintf("tp-%d", profile.ID)
+	s.transcodingProfiles[profile.ID] = &profile
+	writeJSON(w, http.StatusCreated, profile)
+}
+
+func (s *Server) updateTranscodingProfile(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var update transcodingProfile
+	if !decode(w, r, &update) {
+		return
+	}
+	if messages := validateTranscodingProfile(&update); len(messages) > 0 {
+		writeValidationError(w, messages...)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.transcodingProfiles[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Transcoding profile %d not found", id))
+		return
+	}
+	profile.Name = update.Name
+	profile.Content = update.Content
+	writeJSON(w, http.StatusOK, profile)
+}
+
+func (s *Server) deleteTranscodingProfile(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.transcodingProfiles[id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Transcoding profile %d not found", id))
+		return
+	}
+	delete(s.transcodingProfiles, id)
+	writeJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("Transcoding profile %d deleted", id)})
+}
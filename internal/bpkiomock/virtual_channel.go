@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package bpkiomock
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type adBreakInsertion struct {
+	AdServer  *ref `json:"adServer,omitempty"`
+	GapFiller *ref `json:"gapFiller,omitempty"`
+}
+
+// virtualChannelInput is the body of create and update requests.
+type virtualChannelInput struct {
+	Name                string            `json:"name"`
+	Tags                []string          `json:"tags"`
+	BaseLive            *ref              `json:"baseLive,omitempty"`
+	TranscodingProfile  *ref              `json:"transcodingProfile,omitempty"`
+	EnableAdTranscoding bool              `json:"enableAdTranscoding"`
+	AdBreakInsertion    *adBreakInsertion `json:"adBreakInsertion,omitempty"`
+	LivePreRoll         *liveAdPreRoll    `json:"livePreRoll,omitempty"`
+}
+
+// virtualChannel is a stored virtual channel service.
+type virtualChannel struct {
+	ID           int64
+	Input        virtualChannelInput
+	URL          string
+	State        string
+	CreationDate string
+	UpdateDate   string
+}
+
+func (s *Server) registerVirtualChannels(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/services/virtual-channel", s.createVirtualChannel)
+	mux.HandleFunc("GET /v1/services/virtual-channel/{id}", s.getVirtualChannel)
+	mux.HandleFunc("PUT /v1/services/virtual-channel/{id}", s.updateVirtualChannel)
+	mux.HandleFunc("DELETE /v1/services/virtual-channel/{id}", s.deleteVirtualChannel)
+}
+
+// checkVirtualChannel validates input and its references, see
+// checkAdInsertion. The caller holds s.mu.
+func (s *Server) checkVirtualChannel(w http.ResponseWriter, input *virtualChannelInput) bool {
+	messages := validateName(input.Name)
+	if input.BaseLive == nil {
+		messages = append(messages, "baseLive should not be empty")
+	}
+	if len(messages) > 0 {
+		writeValidationError(w, messages...)
+		return false
+	}
+
+	forbidden := func(field string, id int64) bool {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("Access to %s %d is not allowed", field, id))
+		return false
+	}
+
+	if !s.isSource(input.BaseLive, "live") {
+		return forbidden("baseLive", input.BaseLive.ID)
+	}
+	if input.TranscodingProfile != nil {
+		if _, ok := s.transcodingProfiles[input.TranscodingProfile.ID]; !ok {
+			return forbidden("transcodingProfile", input.TranscodingProfile.ID)
+		}
+	}
+	if p := input.AdBreakInsertion; p != nil {
+		if p.AdServer != nil && !s.isSource(p.AdServer, "ad-server") {
+			return forbidden("adBreakInsertion.adServer", p.AdServer.ID)
+		}
+		if p.GapFiller != nil && !s.isSource(p.GapFiller, "slate") {
+			return forbidden("adBreakInsertion.gapFiller", p.GapFiller.ID)
+		}
+	}
+	if p := input.LivePreRoll; p != nil && p.AdServer != nil && !s.isSource(p.AdServer, "ad-server") {
+		return forbidden("livePreRoll.adServer", p.AdServer.ID)
+	}
+	return true
+}
+
+// renderVirtualChannel returns the API representation of svc. The caller
+// holds s.mu.
+func (s *Server) renderVirtualChannel(svc *virtualChannel) map[string]any {
+	out := serviceSummary(svc.ID, "virtual-channel", svc.Input.Name, svc.URL, svc.State, svc.CreationDate, svc.UpdateDate, svc.Input.Tags)
+	out["baseLive"] = s.expand(svc.Input.BaseLive)
+	out["transcodingProfile"] = s.expand(svc.Input.TranscodingProfile)
+	out["enableAdTranscoding"] = svc.Input.EnableAdTranscoding
+
+	if p := svc.Input.AdBreakInsertion; p != nil {
+		out["adBreakInsertion"] = map[string]any{
+			"adServer":  s.expand(p.AdServer),
+			"gapFiller": s.expand(p.GapFiller),
+		}
+	}
+	if p := svc.Input.LivePreRoll; p != nil {
+		out["livePreRoll"] = map[string]any{
+			"adServer":    s.expand(p.AdServer),
+			"maxDuration": p.MaxDuration,
+			"offset":      p.Offset,
+		}
+	}
+	return out
+}
+
+func (s *Server) createVirtualChannel(w http.ResponseWriter, r *http.Request) {
+	var input virtualChannelInput
+	if !decode(w, r, &input) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.checkVirtualChannel(w, &input) {
+		return
+	}
+
+	id := s.newID()
+	svc := &virtualChannel{
+		ID:           id,
+		Input:        input,
+		URL:          fmt.Sprintf("https://stream.broadpeak.io/%032x/", id),
+		State:        "enabled",
+		CreationDate: now(),
+	}
+	svc.UpdateDate = svc.CreationDate
+	s.virtualChannels[id] = svc
+	writeJSON(w, http.StatusCreated, s.renderVirtualChannel(svc))
+}
+
+func (s *Server) getVirtualChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc, ok := s.virtualChannels[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderVirtualChannel(svc))
+}
+
+func (s *Server) updateVirtualChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var input virtualChannelInput
+	if !decode(w, r, &input) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svc, ok := s.virtualChannels[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	if !s.checkVirtualChannel(w, &input) {
+		return
+	}
+
+	svc.Input = input
+	svc.UpdateDate = now()
+	writeJSON(w, http.StatusOK, s.renderVirtualChannel(svc))
+}
+
+func (s *Server) deleteVirtualChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.virtualChannels[id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Service %d not found", id))
+		return
+	}
+	delete(s.virtualChannels, id)
+	writeJSON(w, http.StatusOK, map[string]string{"message": fmt.Sprintf("Service %d deleted", id)})
+}
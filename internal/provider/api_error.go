@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// apiError is returned by the provider's own calls to the Broadpeak API when
+// the server answers with a non-2xx status code.
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// sdkStatusPattern extracts the status code from the message of SDK errors,
+// which read `error: <code>, body: <body>`. It is anchored so that the echoed
+// body, which may mention IDs, URLs or "not found", is never matched.
+var sdkStatusPattern = regexp.MustCompile(`^error: (\d{3})\b`)
+
+// statusCode returns the HTTP status code behind err, from an apiError or
+// from the prefix of an SDK error message, possibly wrapped, and 0 when it
+// is unknown.
+func statusCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	for ; err != nil; err = errors.Unwrap(err) {
+		if m := sdkStatusPattern.FindStringSubmatch(err.Error()); m != nil {
+			code, _ := strconv.Atoi(m[1])
+			return code
+		}
+	}
+	return 0
+}
+
+// isNotFound reports whether err means that the requested object does not
+// exist (any more) on the Broadpeak side. Read uses it to drop objects deleted
+// outside of Terraform from the state so that the next plan re-creates them,
+// while auth failures and server errors are still reported.
+func isNotFound(err error) bool {
+	return statusCode(err) == http.StatusNotFound
+}
+
+// isConflict reports whether err means that the request conflicts with the
+// current state of the object, such as deleting a source that services still
+// use.
+func isConflict(err error) bool {
+	return statusCode(err) == http.StatusConflict
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "api error 404", err: &apiError{StatusCode: http.StatusNotFound}, want: true},
+		{name: "wrapped api error 404", err: fmt.Errorf("get live: %w", &apiError{StatusCode: http.StatusNotFound}), want: true},
+		{name: "api error 401", err: &apiError{StatusCode: http.StatusUnauthorized, Body: "not found"}, want: false},
+		{name: "api error 500", err: &apiError{StatusCode: http.StatusInternalServerError}, want: false},
+		{name: "sdk 404", err: errors.New("error: 404, body: {\"message\":\"Source 12 does not exist\"}"), want: true},
+		{name: "sdk 500 mentioning not found", err: errors.New("error: 500, body: upstream not found"), want: false},
+		{name: "sdk 401 with 404 in body", err: errors.New("error: 401, body: {\"message\":\"key 404 revoked\"}"), want: false},
+		{name: "wrapped sdk 404", err: fmt.Errorf("get slate: %w", errors.New("error: 404, body: Not Found")), want: true},
+		{name: "no status code", err: errors.New("Not Found"), want: false},
+		{name: "sdk forbidden", err: errors.New("error: 403, body: Forbidden"), want: false},
+		{name: "sdk bad gateway", err: errors.New("error: 502, body: Bad Gateway"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isNotFound(tt.err))
+		})
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "api error 409", err: &apiError{StatusCode: http.StatusConflict}, want: true},
+		{name: "wrapped api error 409", err: fmt.Errorf("delete slate: %w", &apiError{StatusCode: http.StatusConflict}), want: true},
+		{name: "api error 400", err: &apiError{StatusCode: http.StatusBadRequest, Body: "conflict"}, want: false},
+		{name: "sdk 409", err: errors.New("error: 409, body: {\"message\":\"Source 12 is used by a service\"}"), want: true},
+		{name: "sdk 500 mentioning conflict", err: errors.New("error: 500, body: write conflict, try again"), want: false},
+		{name: "no status code", err: errors.New("Conflict"), want: false},
+		{name: "sdk 404", err: errors.New("error: 404, body: Not Found"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isConflict(tt.err))
+		})
+	}
+}
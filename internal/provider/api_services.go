@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+)
+
+// apiRef references another object of the tenant by ID.
+type apiRef struct {
+	Id uint `json:"id"`
+}
+
+// apiSourceRef is a source as expanded in service responses.
+type apiSourceRef struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Url  string `json:"url"`
+}
+
+// apiTranscodingProfileRef is a transcoding profile as expanded in service
+// responses.
+type apiTranscodingProfileRef struct {
+	Id         uint   `json:"id"`
+	Name       string `json:"name"`
+	InternalId string `json:"internalId"`
+	Content    string `json:"content"`
+}
+
+// apiContentReplacementInput is the body of content replacement create and
+// update requests.
+type apiContentReplacementInput struct {
+	Name               string   `json:"name"`
+	Tags               []string `json:"tags"`
+	Source             apiRef   `json:"source"`
+	Replacement        apiRef   `json:"replacement"`
+	TranscodingProfile *apiRef  `json:"transcodingProfile,omitempty"`
+}
+
+// apiContentReplacement is a content replacement service.
+type apiContentReplacement struct {
+	Id                 uint                     `json:"id"`
+	Name               string                   `json:"name"`
+	Type               string                   `json:"type"`
+	Url                string                   `json:"url"`
+	CreationDate       string                   `json:"creationDate"`
+	UpdateDate         string                   `json:"updateDate"`
+	State              string                   `json:"state"`
+	Tags               []string                 `json:"tags"`
+	Source             apiSourceRef             `json:"source"`
+	Replacement        apiSourceRef             `json:"replacement"`
+	TranscodingProfile apiTranscodingProfileRef `json:"transcodingProfile"`
+}
+
+// CreateContentReplacement creates a content replacement service.
+func (c *bpkioClient) CreateContentReplacement(ctx context.Context, input apiContentReplacementInput) (apiContentReplacement, error) {
+	var out apiContentReplacement
+	err := c.do(ctx, http.MethodPost, "/v1/services/content-replacement", input, &out)
+	return out, err
+}
+
+// GetContentReplacement returns the content replacement service with the
+// given ID.
+func (c *bpkioClient) GetContentReplacement(ctx context.Context, id uint) (apiContentReplacement, error) {
+	var out apiContentReplacement
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/services/content-replacement/%d", id), nil, &out)
+	return out, err
+}
+
+// UpdateContentReplacement replaces the content replacement service with the
+// given ID.
+func (c *bpkioClient) UpdateContentReplacement(ctx context.Context, id uint, input apiContentReplacementInput) (apiContentReplacement, error) {
+	var out apiContentReplacement
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/services/content-replacement/%d", id), input, &out)
+	return out, err
+}
+
+// DeleteContentReplacement deletes the content replacement service with the
+// given ID.
+func (c *bpkioClient) DeleteContentReplacement(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/services/content-replacement/%d", id), nil, nil)
+}
+
+// apiAdBreakInsertionInput configures the ads inserted in the ad breaks of a
+// virtual channel.
+type apiAdBreakInsertionInput struct {
+	AdServer  *apiRef `json:"adServer,omitempty"`
+	GapFiller *apiRef `json:"gapFiller,omitempty"`
+}
+
+type apiAdBreakInsertion struct {
+	AdServer  *apiSourceRef `json:"adServer,omitempty"`
+	GapFiller *apiSourceRef `json:"gapFiller,omitempty"`
+}
+
+// apiLivePreRollInput configures the pre-roll played when a viewer joins a
+// virtual channel.
+type apiLivePreRollInput struct {
+	AdServer    *apiRef `json:"adServer,omitempty"`
+	MaxDuration uint    `json:"maxDuration"`
+	Offset      uint    `json:"offset"`
+}
+
+type apiLivePreRoll struct {
+	AdServer    *apiSourceRef `json:"adServer,omitempty"`
+	MaxDuration uint          `json:"maxDuration"`
+	Offset      uint          `json:"offset"`
+}
+
+// apiVirtualChannelInput is the body of virtual channel create and update
+// requests.
+type apiVirtualChannelInput struct {
+	Name                string                    `json:"name"`
+	Tags                []string                  `json:"tags"`
+	BaseLive            apiRef                    `json:"baseLive"`
+	TranscodingProfile  *apiRef                   `json:"transcodingProfile,omitempty"`
+	EnableAdTranscoding bool                      `json:"enableAdTranscoding"`
+	AdBreakInsertion    *apiAdBreakInsertionInput `json:"adBreakInsertion,omitempty"`
+	LivePreRoll         *apiLivePreRollInput      `json:"livePreRoll,omitempty"`
+}
+
+// apiVirtualChannel is a virtual channel service.
+type apiVirtualChannel struct {
+	Id                  uint                     `json:"id"`
+	Name                string                   `json:"name"`
+	Type                string                   `json:"type"`
+	Url                 string                   `json:"url"`
+	CreationDate        string                   `json:"creationDate"`
+	UpdateDate          string                   `json:"updateDate"`
+	State               string                   `json:"state"`
+	Tags                []string                 `json:"tags"`
+	BaseLive            apiSourceRef             `json:"baseLive"`
+	TranscodingProfile  apiTranscodingProfileRef `json:"transcodingProfile"`
+	EnableAdTranscoding bool                     `json:"enableAdTranscoding"`
+	AdBreakInsertion    *apiAdBreakInsertion     `json:"adBreakInsertion,omitempty"`
+	LivePreRoll         *apiLivePreRoll          `json:"livePreRoll,omitempty"`
+}
+
+// CreateVirtualChannel creates a virtual channel service.
+func (c *bpkioClient) CreateVirtualChannel(ctx context.Context, input apiVirtualChannelInput) (apiVirtualChannel, error) {
+	var out apiVirtualChannel
+	err := c.do(ctx, http.MethodPost, "/v1/services/virtual-channel", input, &out)
+	return out, err
+}
+
+// GetVirtualChannel returns the virtual channel service with the given ID.
+func (c *bpkioClient) GetVirtualChannel(ctx context.Context, id uint) (apiVirtualChannel, error) {
+	var out apiVirtualChannel
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/services/virtual-channel/%d", id), nil, &out)
+	return out, err
+}
+
+// UpdateVirtualChannel replaces the virtual channel service with the given
+// ID.
+func (c *bpkioClient) UpdateVirtualChannel(ctx context.Context, id uint, input apiVirtualChannelInput) (apiVirtualChannel, error) {
+	var out apiVirtualChannel
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/services/virtual-channel/%d", id), input, &out)
+	return out, err
+}
+
+// DeleteVirtualChannel deletes the virtual channel service with the given ID.
+func (c *bpkioClient) DeleteVirtualChannel(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/services/virtual-channel/%d", id), nil, nil)
+}
+
+// apiAdInsertionInput is the body of ad insertion create and update requests.
+// The SDK input does not model VOD ad insertion, so ad insertion services are
+// written through the REST API directly.
+type apiAdInsertionInput struct {
+	broadpeakio.CreateAdInsertionInput
+	VodAdInsertion *apiVodAdInsertionInput `json:"vodAdInsertion,omitempty"`
+}
+
+// apiVodAdInsertionInput inserts ads into VOD sources.
+type apiVodAdInsertionInput struct {
+	AdServer *apiRef `json:"adServer,omitempty"`
+}
+
+// apiAdInsertion is an ad insertion service, with its VOD ad insertion.
+type apiAdInsertion struct {
+	broadpeakio.AdInsertionOutput
+	VodAdInsertion apiVodAdInsertion `json:"vodAdInsertion"`
+}
+
+// apiVodAdInsertion is the VOD ad insertion of a service, with its ad server
+// expanded.
+type apiVodAdInsertion struct {
+	AdServer broadpeakio.AdServer `json:"adServer"`
+}
+
+// CreateAdInsertion creates an ad insertion service.
+func (c *bpkioClient) CreateAdInsertion(ctx context.Context, input apiAdInsertionInput) (apiAdInsertion, error) {
+	var out apiAdInsertion
+	err := c.do(ctx, http.MethodPost, "/v1/services/ad-insertion", input, &out)
+	return out, err
+}
+
+// GetAdInsertion returns the ad insertion service with the given ID.
+func (c *bpkioClient) GetAdInsertion(ctx context.Context, id uint) (apiAdInsertion, error) {
+	var out apiAdInsertion
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/services/ad-insertion/%d", id), nil, &out)
+	return out, err
+}
+
+// UpdateAdInsertion replaces the ad insertion service with the given ID.
+func (c *bpkioClient) UpdateAdInsertion(ctx context.Context, id uint, input apiAdInsertionInput) (apiAdInsertion, error) {
+	var out apiAdInsertion
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/services/ad-insertion/%d", id), input, &out)
+	return out, err
+}
+
+// serviceStateActions maps the states of a service onto the API call that
+// switches the service to it.
+var serviceStateActions = map[string]string{
+	"enabled":  "enable",
+	"paused":   "pause",
+	"bypassed": "bypass",
+}
+
+// SetAdInsertionState switches the ad insertion service with the given ID to
+// state, one of `enabled`, `paused` and `bypassed`. The SDK only reports the
+// state of services.
+func (c *bpkioClient) SetAdInsertionState(ctx context.Context, id uint, state string) error {
+	action, ok := serviceStateActions[state]
+	if !ok {
+		return fmt.Errorf("unknown service state %q", state)
+	}
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/services/ad-insertion/%d/%s", id, action), nil, nil)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// apiCategoryRef is a category as expanded in slot responses.
+type apiCategoryRef struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// apiSlotInput is the body of slot create and update requests. Exactly one
+// of EndTime and Duration is set.
+type apiSlotInput struct {
+	Name        string  `json:"name,omitempty"`
+	StartTime   string  `json:"startTime"`
+	EndTime     string  `json:"endTime,omitempty"`
+	Duration    uint    `json:"duration,omitempty"`
+	Replacement apiRef  `json:"replacement"`
+	Category    *apiRef `json:"category,omitempty"`
+}
+
+// apiSlot is a time-boxed slot of a virtual channel or content replacement
+// service. The API answers with both its end time and its duration.
+type apiSlot struct {
+	Id          uint            `json:"id"`
+	Name        string          `json:"name"`
+	StartTime   string          `json:"startTime"`
+	EndTime     string          `json:"endTime"`
+	Duration    uint            `json:"duration"`
+	Replacement apiSourceRef    `json:"replacement"`
+	Category    *apiCategoryRef `json:"category,omitempty"`
+}
+
+func slotsPath(serviceType string, serviceID uint) string {
+	return fmt.Sprintf("/v1/services/%s/%d/slots", serviceType, serviceID)
+}
+
+func (c *bpkioClient) createSlot(ctx context.Context, serviceType string, serviceID uint, input apiSlotInput) (apiSlot, error) {
+	var out apiSlot
+	err := c.do(ctx, http.MethodPost, slotsPath(serviceType, serviceID), input, &out)
+	return out, err
+}
+
+func (c *bpkioClient) getSlot(ctx context.Context, serviceType string, serviceID, id uint) (apiSlot, error) {
+	var out apiSlot
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("%s/%d", slotsPath(serviceType, serviceID), id), nil, &out)
+	return out, err
+}
+
+func (c *bpkioClient) updateSlot(ctx context.Context, serviceType string, serviceID, id uint, input apiSlotInput) (apiSlot, error) {
+	var out apiSlot
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("%s/%d", slotsPath(serviceType, serviceID), id), input, &out)
+	return out, err
+}
+
+func (c *bpkioClient) deleteSlot(ctx context.Context, serviceType string, serviceID, id uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", slotsPath(serviceType, serviceID), id), nil, nil)
+}
+
+// ListVirtualChannelSlots returns a page of the slots of a virtual channel
+// service that start between from and to.
+func (c *bpkioClient) ListVirtualChannelSlots(ctx context.Context, serviceID uint, from, to string, offset, limit int) ([]apiSlot, error) {
+	query := url.Values{
+		"from":   {from},
+		"to":     {to},
+		"offset": {strconv.Itoa(offset)},
+		"limit":  {strconv.Itoa(limit)},
+	}
+	var out []apiSlot
+	err := c.do(ctx, http.MethodGet, slotsPath("virtual-channel", serviceID)+"?"+query.Encode(), nil, &out)
+	return out, err
+}
+
+// CreateVirtualChannelSlot schedules a slot on a virtual channel service.
+func (c *bpkioClient) CreateVirtualChannelSlot(ctx context.Context, serviceID uint, input apiSlotInput) (apiSlot, error) {
+	return c.createSlot(ctx, "virtual-channel", serviceID, input)
+}
+
+// GetVirtualChannelSlot returns a slot of a virtual channel service.
+func (c *bpkioClient) GetVirtualChannelSlot(ctx context.Context, serviceID, id uint) (apiSlot, error) {
+	return c.getSlot(ctx, "virtual-channel", serviceID, id)
+}
+
+// UpdateVirtualChannelSlot replaces a slot of a virtual channel service.
+func (c *bpkioClient) UpdateVirtualChannelSlot(ctx context.Context, serviceID, id uint, input apiSlotInput) (apiSlot, error) {
+	return c.updateSlot(ctx, "virtual-channel", serviceID, id, input)
+}
+
+// DeleteVirtualChannelSlot deletes a slot of a virtual channel service.
+func (c *bpkioClient) DeleteVirtualChannelSlot(ctx context.Context, serviceID, id uint) error {
+	return c.deleteSlot(ctx, "virtual-channel", serviceID, id)
+}
+
+// CreateContentReplacementSlot schedules a slot on a content replacement
+// service.
+func (c *bpkioClient) CreateContentReplacementSlot(ctx context.Context, serviceID uint, input apiSlotInput) (apiSlot, error) {
+	return c.createSlot(ctx, "content-replacement", serviceID, input)
+}
+
+// GetContentReplacementSlot returns a slot of a content replacement service.
+func (c *bpkioClient) GetContentReplacementSlot(ctx context.Context, serviceID, id uint) (apiSlot, error) {
+	return c.getSlot(ctx, "content-replacement", serviceID, id)
+}
+
+// UpdateContentReplacementSlot replaces a slot of a content replacement
+// service.
+func (c *bpkioClient) UpdateContentReplacementSlot(ctx context.Context, serviceID, id uint, input apiSlotInput) (apiSlot, error) {
+	return c.updateSlot(ctx, "content-replacement", serviceID, id, input)
+}
+
+// DeleteContentReplacementSlot deletes a slot of a content replacement
+// service.
+func (c *bpkioClient) DeleteContentReplacementSlot(ctx context.Context, serviceID, id uint) error {
+	return c.deleteSlot(ctx, "content-replacement", serviceID, id)
+}
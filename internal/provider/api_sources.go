@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type apiCustomHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type apiOrigin struct {
+	CustomHeaders []apiCustomHeader `json:"customHeaders"`
+}
+
+// apiSource is a source managed through the REST endpoints called directly
+// by the provider. Fields that do not apply to a source type are omitted.
+type apiSource struct {
+	Id          uint      `json:"id,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Url         string    `json:"url"`
+	Format      string    `json:"format,omitempty"`
+	AssetSample string    `json:"assetSample,omitempty"`
+	Origin      apiOrigin `json:"origin"`
+}
+
+func (c *bpkioClient) createSource(ctx context.Context, sourceType string, input apiSource) (apiSource, error) {
+	var out apiSource
+	err := c.do(ctx, http.MethodPost, "/v1/sources/"+sourceType, input, &out)
+	return out, err
+}
+
+func (c *bpkioClient) getSource(ctx context.Context, sourceType string, id uint) (apiSource, error) {
+	var out apiSource
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/sources/%s/%d", sourceType, id), nil, &out)
+	return out, err
+}
+
+func (c *bpkioClient) updateSource(ctx context.Context, sourceType string, id uint, input apiSource) (apiSource, error) {
+	var out apiSource
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/sources/%s/%d", sourceType, id), input, &out)
+	return out, err
+}
+
+func (c *bpkioClient) deleteSource(ctx context.Context, sourceType string, id uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/sources/%s/%d", sourceType, id), nil, nil)
+}
+
+// CreateAsset creates a VOD asset source.
+func (c *bpkioClient) CreateAsset(ctx context.Context, input apiSource) (apiSource, error) {
+	return c.createSource(ctx, "asset", input)
+}
+
+// GetAsset returns the VOD asset source with the given ID.
+func (c *bpkioClient) GetAsset(ctx context.Context, id uint) (apiSource, error) {
+	return c.getSource(ctx, "asset", id)
+}
+
+// UpdateAsset replaces the VOD asset source with the given ID.
+func (c *bpkioClient) UpdateAsset(ctx context.Context, id uint, input apiSource) (apiSource, error) {
+	return c.updateSource(ctx, "asset", id, input)
+}
+
+// DeleteAsset deletes the VOD asset source with the given ID.
+func (c *bpkioClient) DeleteAsset(ctx context.Context, id uint) error {
+	return c.deleteSource(ctx, "asset", id)
+}
+
+// CreateAssetCatalog creates an asset catalog source.
+func (c *bpkioClient) CreateAssetCatalog(ctx context.Context, input apiSource) (apiSource, error) {
+	return c.createSource(ctx, "asset-catalog", input)
+}
+
+// GetAssetCatalog returns the asset catalog source with the given ID.
+func (c *bpkioClient) GetAssetCatalog(ctx context.Context, id uint) (apiSource, error) {
+	return c.getSource(ctx, "asset-catalog", id)
+}
+
+// UpdateAssetCatalog replaces the asset catalog source with the given ID.
+func (c *bpkioClient) UpdateAssetCatalog(ctx context.Context, id uint, input apiSource) (apiSource, error) {
+	return c.updateSource(ctx, "asset-catalog", id, input)
+}
+
+// DeleteAssetCatalog deletes the asset catalog source with the given ID.
+func (c *bpkioClient) DeleteAssetCatalog(ctx context.Context, id uint) error {
+	return c.deleteSource(ctx, "asset-catalog", id)
+}
+
+// CreateOrigin creates an origin source.
+func (c *bpkioClient) CreateOrigin(ctx context.Context, input apiSource) (apiSource, error) {
+	return c.createSource(ctx, "origin", input)
+}
+
+// GetOrigin returns the origin source with the given ID.
+func (c *bpkioClient) GetOrigin(ctx context.Context, id uint) (apiSource, error) {
+	return c.getSource(ctx, "origin", id)
+}
+
+// UpdateOrigin replaces the origin source with the given ID.
+func (c *bpkioClient) UpdateOrigin(ctx context.Context, id uint, input apiSource) (apiSource, error) {
+	return c.updateSource(ctx, "origin", id, input)
+}
+
+// DeleteOrigin deletes the origin source with the given ID.
+func (c *bpkioClient) DeleteOrigin(ctx context.Context, id uint) error {
+	return c.deleteSource(ctx, "origin", id)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// apiTranscodingProfileInput is the body of transcoding profile create and
+// update requests. Content is the profile JSON document, serialized as a
+// string.
+type apiTranscodingProfileInput struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// apiTranscodingProfile is a transcoding profile as answered by the write
+// endpoints, which the SDK does not cover. Reads go through the SDK.
+type apiTranscodingProfile struct {
+	Id         uint   `json:"id"`
+	Name       string `json:"name"`
+	Content    string `json:"content"`
+	InternalId string `json:"internalId"`
+}
+
+// CreateTranscodingProfile creates a transcoding profile. Tenants that are
+// not allowed to manage profiles get a 403.
+func (c *bpkioClient) CreateTranscodingProfile(ctx context.Context, input apiTranscodingProfileInput) (apiTranscodingProfile, error) {
+	var out apiTranscodingProfile
+	err := c.do(ctx, http.MethodPost, "/v1/transcoding-profiles", input, &out)
+	return out, err
+}
+
+// UpdateTranscodingProfile replaces the name and content of a transcoding
+// profile.
+func (c *bpkioClient) UpdateTranscodingProfile(ctx context.Context, id uint, input apiTranscodingProfileInput) (apiTranscodingProfile, error) {
+	var out apiTranscodingProfile
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/v1/transcoding-profiles/%d", id), input, &out)
+	return out, err
+}
+
+// DeleteTranscodingProfile deletes a transcoding profile.
+func (c *bpkioClient) DeleteTranscodingProfile(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/v1/transcoding-profiles/%d", id), nil, nil)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+)
+
+// Ensure the provider client satisfies the interface used by resources.
+var _ apiClient = &bpkioClient{}
+
+// apiClient is the Broadpeak API as used by resources and data sources. The
+// provider hands them a *bpkioClient; unit tests hand them the in-memory fake
+// instead.
+type apiClient interface {
+	CreateLive(input broadpeakio.LiveInput) (broadpeakio.Source, error)
+	GetLive(id uint) (broadpeakio.Source, error)
+	UpdateLive(id uint, input broadpeakio.LiveInput) (broadpeakio.Source, error)
+	DeleteLive(id uint) (string, error)
+
+	CreateSlate(input broadpeakio.SlateInput) (broadpeakio.Source, error)
+	GetSlate(id uint) (broadpeakio.Source, error)
+	UpdateSlate(id uint, input broadpeakio.SlateInput) (broadpeakio.Source, error)
+	DeleteSlate(id uint) (string, error)
+
+	CreateAdServer(input broadpeakio.AdServerInput) (broadpeakio.AdServer, error)
+	GetAdServer(id uint) (broadpeakio.AdServer, error)
+	UpdateAdServer(id uint, input broadpeakio.AdServerInput) (broadpeakio.AdServer, error)
+	DeleteAdServer(id uint) (string, error)
+
+	CreateAdInsertion(ctx context.Context, input apiAdInsertionInput) (apiAdInsertion, error)
+	GetAdInsertion(ctx context.Context, id uint) (apiAdInsertion, error)
+	UpdateAdInsertion(ctx context.Context, id uint, input apiAdInsertionInput) (apiAdInsertion, error)
+	DeleteAdInsertion(id uint) (string, error)
+	SetAdInsertionState(ctx context.Context, id uint, state string) error
+
+	GetAllSources(offset int, limit int) ([]broadpeakio.Source, error)
+	GetAllServices(offset int, limit int) ([]broadpeakio.ServiceOutput, error)
+
+	GetTranscodingProfile(id uint) (broadpeakio.TranscodingProfile, error)
+	GetAllTranscodingProfiles(offset int, limit int) ([]broadpeakio.TranscodingProfile, error)
+
+	CreateTranscodingProfile(ctx context.Context, input apiTranscodingProfileInput) (apiTranscodingProfile, error)
+	UpdateTranscodingProfile(ctx context.Context, id uint, input apiTranscodingProfileInput) (apiTranscodingProfile, error)
+	DeleteTranscodingProfile(ctx context.Context, id uint) error
+
+	CreateAsset(ctx context.Context, input apiSource) (apiSource, error)
+	GetAsset(ctx context.Context, id uint) (apiSource, error)
+	UpdateAsset(ctx context.Context, id uint, input apiSource) (apiSource, error)
+	DeleteAsset(ctx context.Context, id uint) error
+
+	CreateAssetCatalog(ctx context.Context, input apiSource) (apiSource, error)
+	GetAssetCatalog(ctx context.Context, id uint) (apiSource, error)
+	UpdateAssetCatalog(ctx context.Context, id uint, input apiSource) (apiSource, error)
+	DeleteAssetCatalog(ctx context.Context, id uint) error
+
+	CreateOrigin(ctx context.Context, input apiSource) (apiSource, error)
+	GetOrigin(ctx context.Context, id uint) (apiSource, error)
+	UpdateOrigin(ctx context.Context, id uint, input apiSource) (apiSource, error)
+	DeleteOrigin(ctx context.Context, id uint) error
+
+	CreateContentReplacement(ctx context.Context, input apiContentReplacementInput) (apiContentReplacement, error)
+	GetContentReplacement(ctx context.Context, id uint) (apiContentReplacement, error)
+	UpdateContentReplacement(ctx context.Context, id uint, input apiContentReplacementInput) (apiContentReplacement, error)
+	DeleteContentReplacement(ctx context.Context, id uint) error
+
+	CreateVirtualChannel(ctx context.Context, input apiVirtualChannelInput) (apiVirtualChannel, error)
+	GetVirtualChannel(ctx context.Context, id uint) (apiVirtualChannel, error)
+	UpdateVirtualChannel(ctx context.Context, id uint, input apiVirtualChannelInput) (apiVirtualChannel, error)
+	DeleteVirtualChannel(ctx context.Context, id uint) error
+
+	CreateVirtualChannelSlot(ctx context.Context, serviceID uint, input apiSlotInput) (apiSlot, error)
+	GetVirtualChannelSlot(ctx context.Context, serviceID, id uint) (apiSlot, error)
+	UpdateVirtualChannelSlot(ctx context.Context, serviceID, id uint, input apiSlotInput) (apiSlot, error)
+	DeleteVirtualChannelSlot(ctx context.Context, serviceID, id uint) error
+	ListVirtualChannelSlots(ctx context.Context, serviceID uint, from, to string, offset, limit int) ([]apiSlot, error)
+
+	CreateContentReplacementSlot(ctx context.Context, serviceID uint, input apiSlotInput) (apiSlot, error)
+	GetContentReplacementSlot(ctx context.Context, serviceID, id uint) (apiSlot, error)
+	UpdateContentReplacementSlot(ctx context.Context, serviceID, id uint, input apiSlotInput) (apiSlot, error)
+	DeleteContentReplacementSlot(ctx context.Context, serviceID, id uint) error
+}
+
+// bpkioClient is the SDK client, extended with direct REST calls to the
+// endpoints that the SDK does not cover.
+type bpkioClient struct {
+	*broadpeakio.BroadpeakClient
+
+	httpClient *http.Client
+	endpoint   string
+	apiKey     string
+}
+
+func newBPKIOClient(apiKey, endpoint string, httpClient *http.Client) *bpkioClient {
+	sdk := broadpeakio.MakeClientWithUrl(apiKey, endpoint)
+	sdk.HTTPClient = httpClient
+
+	return &bpkioClient{
+		BroadpeakClient: &sdk,
+		httpClient:      httpClient,
+		endpoint:        endpoint,
+		apiKey:          apiKey,
+	}
+}
+
+// do sends in as the JSON body of a request to the API and decodes the JSON
+// answer into out. Either may be nil. Non-2xx answers are returned as
+// *apiError. Canceling ctx aborts the request, including its retries.
+func (c *bpkioClient) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &apiError{StatusCode: res.StatusCode, Body: string(raw)}
+	}
+	if out == nil || len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("decoding %s %s answer: %w", method, path, err)
+	}
+	return nil
+}
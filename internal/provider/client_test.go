@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"bpkio-terraform-provider/internal/bpkiomock"
+
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBPKIOClient_Assets(t *testing.T) {
+	ctx := context.Background()
+	srv := bpkiomock.NewServer()
+	defer srv.Close()
+
+	client := newBPKIOClient("test", srv.URL, http.DefaultClient)
+
+	created, err := client.CreateAsset(ctx, apiSource{
+		Name:   "asset",
+		Url:    "https://origin.example.com/movie.mpd",
+		Origin: apiOrigin{CustomHeaders: []apiCustomHeader{{Name: "X-Token", Value: "secret"}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "asset", created.Type)
+	require.Equal(t, "DASH", created.Format)
+
+	updated, err := client.UpdateAsset(ctx, created.Id, apiSource{Name: "renamed", Url: created.Url})
+	require.NoError(t, err)
+	require.Equal(t, "renamed", updated.Name)
+	require.Empty(t, updated.Origin.CustomHeaders)
+
+	got, err := client.GetAsset(ctx, created.Id)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+
+	require.NoError(t, client.DeleteAsset(ctx, created.Id))
+
+	_, err = client.GetAsset(ctx, created.Id)
+	var apiErr *apiError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	require.True(t, isNotFound(err))
+}
+
+func TestBPKIOClient_Slots(t *testing.T) {
+	ctx := context.Background()
+	srv := bpkiomock.NewServer()
+	defer srv.Close()
+
+	client := newBPKIOClient("test", srv.URL, http.DefaultClient)
+
+	var live apiSource
+	require.NoError(t, client.do(ctx, http.MethodPost, "/v1/sources/live", apiSource{Name: "live", Url: "https://origin.example.com/index.m3u8"}, &live))
+	asset, err := client.CreateAsset(ctx, apiSource{Name: "asset", Url: "https://origin.example.com/movie.mp4"})
+	require.NoError(t, err)
+	service, err := client.CreateContentReplacement(ctx, apiContentReplacementInput{
+		Name:        "service",
+		Source:      apiRef{Id: live.Id},
+		Replacement: apiRef{Id: asset.Id},
+	})
+	require.NoError(t, err)
+
+	created, err := client.CreateContentReplacementSlot(ctx, service.Id, apiSlotInput{
+		StartTime:   "2030-01-01T20:00:00Z",
+		Duration:    600,
+		Replacement: apiRef{Id: asset.Id},
+		Category:    &apiRef{Id: 7},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "2030-01-01T20:10:00Z", created.EndTime)
+	require.Equal(t, "asset", created.Replacement.Type)
+	require.Equal(t, uint(7), created.Category.Id)
+
+	updated, err := client.UpdateContentReplacementSlot(ctx, service.Id, created.Id, apiSlotInput{
+		Name:        "renamed",
+		StartTime:   created.StartTime,
+		EndTime:     "2030-01-01T21:00:00Z",
+		Replacement: apiRef{Id: asset.Id},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint(3600), updated.Duration)
+	require.Nil(t, updated.Category)
+
+	got, err := client.GetContentReplacementSlot(ctx, service.Id, created.Id)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+
+	_, err = client.GetVirtualChannelSlot(ctx, service.Id, created.Id)
+	require.True(t, isNotFound(err), "the slot belongs to a content replacement service")
+
+	require.NoError(t, client.DeleteContentReplacementSlot(ctx, service.Id, created.Id))
+	_, err = client.GetContentReplacementSlot(ctx, service.Id, created.Id)
+	require.True(t, isNotFound(err))
+}
+
+func TestBPKIOClient_ListVirtualChannelSlots(t *testing.T) {
+	ctx := context.Background()
+	srv := bpkiomock.NewServer()
+	defer srv.Close()
+
+	client := newBPKIOClient("test", srv.URL, http.DefaultClient)
+
+	var live apiSource
+	require.NoError(t, client.do(ctx, http.MethodPost, "/v1/sources/live", apiSource{Name: "live", Url: "https://origin.example.com/index.m3u8"}, &live))
+	service, err := client.CreateVirtualChannel(ctx, apiVirtualChannelInput{Name: "channel", BaseLive: apiRef{Id: live.Id}})
+	require.NoError(t, err)
+
+	for _, start := range []string{"2030-01-01T23:00:00Z", "2030-01-01T20:00:00Z", "2030-01-02T00:00:00Z"} {
+		_, err := client.CreateVirtualChannelSlot(ctx, service.Id, apiSlotInput{StartTime: start, Duration: 600, Replacement: apiRef{Id: live.Id}})
+		require.NoError(t, err)
+	}
+
+	slots, err := client.ListVirtualChannelSlots(ctx, service.Id, "2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, slots, 2, "the slot starting at the end of the window is left out")
+	require.Equal(t, "2030-01-01T20:00:00Z", slots[0].StartTime)
+
+	slots, err = client.ListVirtualChannelSlots(ctx, service.Id, "2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	require.Equal(t, "2030-01-01T23:00:00Z", slots[0].StartTime)
+}
+
+func TestBPKIOClient_TranscodingProfiles(t *testing.T) {
+	ctx := context.Background()
+	srv := bpkiomock.NewServer()
+	defer srv.Close()
+
+	client := newBPKIOClient("test", srv.URL, http.DefaultClient)
+
+	created, err := client.CreateTranscodingProfile(ctx, apiTranscodingProfileInput{Name: "profile", Content: `{"jobs":[]}`})
+	require.NoError(t, err)
+	require.NotZero(t, created.Id)
+	require.NotEmpty(t, created.InternalId)
+
+	updated, err := client.UpdateTranscodingProfile(ctx, created.Id, apiTranscodingProfileInput{Name: "renamed", Content: `{}`})
+	require.NoError(t, err)
+	require.Equal(t, "renamed", updated.Name)
+	require.Equal(t, created.InternalId, updated.InternalId)
+
+	require.NoError(t, client.DeleteTranscodingProfile(ctx, created.Id))
+	require.True(t, isNotFound(client.DeleteTranscodingProfile(ctx, created.Id)))
+
+	srv.InjectFailure(bpkiomock.Failure{Method: http.MethodPost, PathPrefix: "/v1/transcoding-profiles", Status: http.StatusForbidden, Count: 1})
+	_, err = client.CreateTranscodingProfile(ctx, apiTranscodingProfileInput{Name: "profile", Content: `{}`})
+	var apiErr *apiError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+}
+
+func TestBPKIOClient_AdInsertionVOD(t *testing.T) {
+	ctx := context.Background()
+	srv := bpkiomock.NewServer()
+	defer srv.Close()
+
+	client := newBPKIOClient("test", srv.URL, http.DefaultClient)
+
+	asset, err := client.CreateAsset(ctx, apiSource{Name: "asset", Url: "https://origin.example.com/movie.mpd"})
+	require.NoError(t, err)
+	var adServer apiSourceRef
+	require.NoError(t, client.do(ctx, http.MethodPost, "/v1/sources/ad-server", map[string]string{"name": "ads", "url": "https://ads.example.com/vast"}, &adServer))
+
+	input := apiAdInsertionInput{
+		CreateAdInsertionInput: broadpeakio.CreateAdInsertionInput{
+			Name:   "vod",
+			Source: &broadpeakio.Identifiable{Id: asset.Id},
+		},
+		VodAdInsertion: &apiVodAdInsertionInput{AdServer: &apiRef{Id: adServer.Id}},
+	}
+	created, err := client.CreateAdInsertion(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, adServer.Id, created.VodAdInsertion.AdServer.Id)
+	require.Equal(t, "ads", created.VodAdInsertion.AdServer.Name)
+
+	got, err := client.GetAdInsertion(ctx, created.Id)
+	require.NoError(t, err)
+	require.Equal(t, created.VodAdInsertion, got.VodAdInsertion)
+
+	input.VodAdInsertion = nil
+	updated, err := client.UpdateAdInsertion(ctx, created.Id, input)
+	require.NoError(t, err)
+	require.Zero(t, updated.VodAdInsertion.AdServer.Id)
+}
+
+func TestBPKIOClient_Canceled(t *testing.T) {
+	srv := bpkiomock.NewServer()
+	defer srv.Close()
+
+	client := newBPKIOClient("test", srv.URL, http.DefaultClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetAsset(ctx, 1)
+	require.ErrorIs(t, err, context.Canceled)
+}
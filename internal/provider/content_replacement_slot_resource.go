@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// NewContentReplacementSlotResource is a helper function to simplify the provider implementation.
+func NewContentReplacementSlotResource() resource.Resource {
+	return &serviceSlotResource{
+		typeName:    "content_replacement_slot",
+		service:     "content replacement",
+		replacement: "The slate or asset source played instead of the live content during the slot.",
+		api: slotMethods{
+			create: apiClient.CreateContentReplacementSlot,
+			get:    apiClient.GetContentReplacementSlot,
+			update: apiClient.UpdateContentReplacementSlot,
+			delete: apiClient.DeleteContentReplacementSlot,
+		},
+	}
+}
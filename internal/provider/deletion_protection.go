@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resourceClient is the provider data handed to resources: the API client,
+// with the provider settings that resources apply themselves.
+type resourceClient struct {
+	apiClient
+
+	// deletionProtection is the value of deletion_protection for resources
+	// that do not set it.
+	deletionProtection bool
+}
+
+// providerDeletionProtection returns the provider default of
+// deletion_protection from the data handed to a resource's Configure.
+func providerDeletionProtection(providerData any) bool {
+	client, ok := providerData.(*resourceClient)
+	return ok && client.deletionProtection
+}
+
+// deletionProtectionAttribute is the deletion_protection attribute of a
+// resource managing the given kind of object.
+func deletionProtectionAttribute(object string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Description: fmt.Sprintf("Whether Terraform refuses to delete the %s, including when it has to be replaced. "+
+			"To delete a protected %s, first apply `deletion_protection = false`. "+
+			"Defaults to the `deletion_protection` setting of the provider.", object, object),
+	}
+}
+
+// planDeletionProtection plans the provider default for deletion_protection
+// when the configuration does not set it.
+func planDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, providerDefault bool) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_protection"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deletion_protection"), providerDefault)...)
+}
+
+// refreshedDeletionProtection returns the deletion_protection of a refreshed
+// state. The API does not know about it, so the prior value is kept, and
+// imported objects get the provider default.
+func refreshedDeletionProtection(prior types.Bool, providerDefault bool) types.Bool {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.BoolValue(providerDefault)
+	}
+	return prior
+}
+
+// checkDeletionProtection reports an error on diags and returns false when the
+// object in state is protected against deletion.
+func checkDeletionProtection(ctx context.Context, state tfsdk.State, diags *diag.Diagnostics, object string, id int64) bool {
+	var protected types.Bool
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if diags.HasError() {
+		return false
+	}
+	if !protected.ValueBool() {
+		return true
+	}
+
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion Protection Enabled",
+		fmt.Sprintf("The %s with ID %d is protected against deletion. "+
+			"Apply deletion_protection = false for it first, then delete it.", object, id),
+	)
+	return false
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderDeletionProtection(t *testing.T) {
+	client := newFakeClient()
+	require.True(t, providerDeletionProtection(&resourceClient{apiClient: client, deletionProtection: true}))
+	require.False(t, providerDeletionProtection(&resourceClient{apiClient: client}))
+	require.False(t, providerDeletionProtection(client))
+}
+
+func TestRefreshedDeletionProtection(t *testing.T) {
+	require.Equal(t, types.BoolValue(true), refreshedDeletionProtection(types.BoolNull(), true))
+	require.Equal(t, types.BoolValue(false), refreshedDeletionProtection(types.BoolUnknown(), false))
+	require.Equal(t, types.BoolValue(false), refreshedDeletionProtection(types.BoolValue(false), true))
+}
+
+func TestSourceSlateResource_DeleteProtected(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	slate, err := client.CreateSlate(broadpeakio.SlateInput{Name: "slate", Url: SlateURL})
+	require.NoError(t, err)
+	r := &sourceSlateResource{client: client}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	deleteSlate := func(protected bool) diag.Diagnostics {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		model := sourceSlateResourceModel{
+			sourceSlateDataSourceModel: sourceSlateDataSourceModel{
+				ID:          types.Int64Value(int64(slate.Id)),
+				Name:        types.StringValue(slate.Name),
+				Type:        types.StringValue(slate.Type),
+				URL:         types.StringValue(slate.Url),
+				Description: types.StringValue(slate.Description),
+				Format:      types.StringValue(slate.Format),
+			},
+			DeletionProtection: types.BoolValue(protected),
+		}
+		require.False(t, state.Set(ctx, model).HasError())
+
+		var resp fwresource.DeleteResponse
+		r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+		return resp.Diagnostics
+	}
+
+	diags := deleteSlate(true)
+	require.True(t, diags.HasError())
+	require.Equal(t, "Deletion Protection Enabled", diags[0].Summary())
+	require.Equal(t, path.Root("deletion_protection"), diags[0].(diag.DiagnosticWithPath).Path())
+	_, err = client.GetSlate(slate.Id)
+	require.NoError(t, err, "a protected slate is kept")
+
+	require.False(t, deleteSlate(false).HasError())
+	_, err = client.GetSlate(slate.Id)
+	require.True(t, isNotFound(err))
+}
+
+func testSourceSlateConfigProtected(providerDefault, protection string) string {
+	return `
+provider "bpkio" {
+  api_key             = "fake"
+  deletion_protection = ` + providerDefault + `
+}
+
+resource "bpkio_source_slate" "test" {
+  name = "slate"
+  url  = "` + SlateURL + `"
+` + protection + `
+}
+`
+}
+
+func TestSourceSlateResource_DeletionProtection(t *testing.T) {
+	testUnitPreCheck(t)
+
+	resourceName := "bpkio_source_slate.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: testSourceSlateConfigProtected("true", ""),
+				Check:  resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+			},
+			{
+				Config:      testSourceSlateConfigProtected("true", ""),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			{
+				// Lifting the protection is an update, after which the slate
+				// can be destroyed.
+				Config: testSourceSlateConfigProtected("true", "  deletion_protection = false"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+			},
+			{
+				Config:            testSourceSlateConfigProtected("false", "  deletion_protection = false"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{name: "seconds", value: types.StringValue("90s")},
+		{name: "compound", value: types.StringValue("1m30s")},
+		{name: "zero", value: types.StringValue("0s")},
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "missing unit", value: types.StringValue("90"), wantErr: true},
+		{name: "negative", value: types.StringValue("-1m"), wantErr: true},
+		{name: "empty", value: types.StringValue(""), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("wait_for_detach"), ConfigValue: tt.value}
+			var resp validator.StringResponse
+			durationValidator{}.ValidateString(context.Background(), req, &resp)
+			require.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
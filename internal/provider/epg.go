@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// epgProgramme is a programme read from an EPG file. Stop is zero when the
+// file does not give it.
+type epgProgramme struct {
+	Channel    string
+	Title      string
+	Categories []string
+	Start      time.Time
+	Stop       time.Time
+}
+
+// epgSlot is a programme mapped onto the source that plays it.
+type epgSlot struct {
+	Programme     epgProgramme
+	ReplacementID int64
+}
+
+// xmltvTimeLayouts are the layouts of XMLTV timestamps, which may omit the
+// time zone (then UTC) and the trailing units.
+var xmltvTimeLayouts = []string{
+	"20060102150405 -0700",
+	"20060102150405",
+	"200601021504 -0700",
+	"200601021504",
+}
+
+// parseXMLTVTime parses an XMLTV timestamp such as `20250601200000 +0200`.
+func parseXMLTVTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range xmltvTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid XMLTV timestamp %q", value)
+}
+
+// parseXMLTV reads the programmes of an XMLTV document.
+func parseXMLTV(r io.Reader) ([]epgProgramme, error) {
+	var doc struct {
+		Programmes []struct {
+			Start      string   `xml:"start,attr"`
+			Stop       string   `xml:"stop,attr"`
+			Channel    string   `xml:"channel,attr"`
+			Titles     []string `xml:"title"`
+			Categories []string `xml:"category"`
+		} `xml:"programme"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parsing XMLTV: %w", err)
+	}
+
+	programmes := make([]epgProgramme, 0, len(doc.Programmes))
+	for i, p := range doc.Programmes {
+		programme := epgProgramme{Channel: p.Channel, Categories: trimAll(p.Categories)}
+		if len(p.Titles) > 0 {
+			programme.Title = strings.TrimSpace(p.Titles[0])
+		}
+
+		var err error
+		if programme.Start, err = parseXMLTVTime(p.Start); err != nil {
+			return nil, fmt.Errorf("programme %d (%q): start: %w", i+1, programme.Title, err)
+		}
+		if p.Stop != "" {
+			if programme.Stop, err = parseXMLTVTime(p.Stop); err != nil {
+				return nil, fmt.Errorf("programme %d (%q): stop: %w", i+1, programme.Title, err)
+			}
+		}
+		programmes = append(programmes, programme)
+	}
+	return programmes, nil
+}
+
+// parseEPGCSV reads programmes from a CSV file with a header row. The
+// `title`, `start_time` and `end_time` columns are required, `channel` and
+// `category` are optional. Times are RFC3339 timestamps and categories are
+// separated by `|`.
+func parseEPGCSV(r io.Reader) ([]epgProgramme, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"title", "start_time", "end_time"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header has no %q column", name)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var programmes []epgProgramme
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return programmes, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading CSV: %w", err)
+		}
+
+		programme := epgProgramme{Channel: field(record, "channel"), Title: field(record, "title")}
+		if category := field(record, "category"); category != "" {
+			programme.Categories = trimAll(strings.Split(category, "|"))
+		}
+		if programme.Start, err = time.Parse(time.RFC3339, field(record, "start_time")); err != nil {
+			return nil, fmt.Errorf("line %d: start_time: %w", line, err)
+		}
+		if programme.Stop, err = time.Parse(time.RFC3339, field(record, "end_time")); err != nil {
+			return nil, fmt.Errorf("line %d: end_time: %w", line, err)
+		}
+		programmes = append(programmes, programme)
+	}
+}
+
+func trimAll(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// fillEPGStops sorts programmes by start time and ends the programmes without
+// a stop time when the next programme of their channel starts. It returns the
+// programmes whose end is still unknown separately.
+func fillEPGStops(programmes []epgProgramme) ([]epgProgramme, []epgProgramme) {
+	sort.SliceStable(programmes, func(i, j int) bool { return programmes[i].Start.Before(programmes[j].Start) })
+
+	var complete, open []epgProgramme
+	for i, programme := range programmes {
+		if programme.Stop.IsZero() {
+			for _, next := range programmes[i+1:] {
+				if next.Channel == programme.Channel && next.Start.After(programme.Start) {
+					programme.Stop = next.Start
+					break
+				}
+			}
+		}
+		if programme.Stop.IsZero() {
+			open = append(open, programme)
+			continue
+		}
+		complete = append(complete, programme)
+	}
+	return complete, open
+}
+
+// mapEPG maps each programme onto the source of its title or, failing that,
+// of its first mapped category. Programmes matching neither are returned
+// separately.
+func mapEPG(programmes []epgProgramme, mapping map[string]int64) ([]epgSlot, []epgProgramme) {
+	var slots []epgSlot
+	var unmapped []epgProgramme
+	for _, programme := range programmes {
+		id, ok := mapping[programme.Title]
+		for _, category := range programme.Categories {
+			if ok {
+				break
+			}
+			id, ok = mapping[category]
+		}
+		if !ok {
+			unmapped = append(unmapped, programme)
+			continue
+		}
+		slots = append(slots, epgSlot{Programme: programme, ReplacementID: id})
+	}
+	return slots, unmapped
+}
+
+// epgOverlaps returns the index pairs of the slots that overlap. Slots are
+// sorted by start time.
+func epgOverlaps(slots []epgSlot) [][2]int {
+	var overlaps [][2]int
+	for i := range slots {
+		for j := i + 1; j < len(slots) && slots[j].Programme.Start.Before(slots[i].Programme.Stop); j++ {
+			overlaps = append(overlaps, [2]int{i, j})
+		}
+	}
+	return overlaps
+}
+
+// epgTime formats t as the RFC3339 timestamp, in UTC, of a slot.
+func epgTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
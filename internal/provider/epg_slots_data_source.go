@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &epgSlotsDataSource{}
+)
+
+// epgSlotsDataSource turns an EPG file into slots. It only reads local files
+// and does not call the Broadpeak API.
+type epgSlotsDataSource struct{}
+
+// NewEPGSlotsDataSource is a helper function to simplify the provider implementation.
+func NewEPGSlotsDataSource() datasource.DataSource {
+	return &epgSlotsDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *epgSlotsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_epg_slots"
+}
+
+// Schema defines the schema for the data source.
+func (d *epgSlotsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the programmes of a local XMLTV or CSV EPG file and maps them onto replacement sources, " +
+			"producing slots that can be fed to `bpkio_virtual_channel_schedule` or `bpkio_virtual_channel_slot`. " +
+			"Programmes that are not mapped and slots that overlap are reported as warnings.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "Path of the EPG file.",
+			},
+			"format": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "Format of the EPG file, `xmltv` or `csv`. Detected from the extension of the file when unset (`.csv` is CSV, anything else XMLTV). " +
+					"CSV files have a header row with `title`, `start_time` and `end_time` columns, and optional `channel` and `category` columns. Times are RFC3339 timestamps and categories are separated by `|`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("xmltv", "csv"),
+				},
+			},
+			"channel": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only read the programmes of this channel ID. All programmes are read when unset.",
+			},
+			"window_start": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only read the programmes starting at or after this RFC3339 timestamp.",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"window_end": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only read the programmes starting before this RFC3339 timestamp.",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"mapping": schema.MapAttribute{
+				Required:    true,
+				ElementType: types.Int64Type,
+				Description: "IDs of the replacement sources, by programme title or category. A programme is mapped by its title first, then by the first of its categories that is mapped. Matching is case sensitive.",
+			},
+			"slots": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Slots of the mapped programmes, sorted by start time.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Title of the programme.",
+						},
+						"start_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "Start of the programme, as an RFC3339 timestamp.",
+						},
+						"end_time": schema.StringAttribute{
+							Computed:    true,
+							Description: "End of the programme, as an RFC3339 timestamp.",
+						},
+						"replacement_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "ID of the source mapped to the programme.",
+						},
+					},
+				},
+			},
+			"unmapped": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Programmes that match no entry of `mapping`, sorted by start time.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{
+							Computed: true,
+						},
+						"categories": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"start_time": schema.StringAttribute{
+							Computed: true,
+						},
+						"end_time": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// epgFormat returns the format of the file at path when format is unset.
+func epgFormat(format types.String, file string) string {
+	if !format.IsNull() && !format.IsUnknown() {
+		return format.ValueString()
+	}
+	if strings.EqualFold(filepath.Ext(file), ".csv") {
+		return "csv"
+	}
+	return "xmltv"
+}
+
+// readEPG reads the programmes of the EPG file at path.
+func readEPG(file, format string) ([]epgProgramme, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if format == "csv" {
+		return parseEPGCSV(f)
+	}
+	return parseXMLTV(f)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *epgSlotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config epgSlotsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	file := config.Path.ValueString()
+	config.Format = types.StringValue(epgFormat(config.Format, file))
+	programmes, err := readEPG(file, config.Format.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to Read EPG File", err.Error())
+		return
+	}
+
+	mapping := map[string]int64{}
+	resp.Diagnostics.Append(config.Mapping.ElementsAs(ctx, &mapping, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Stops are filled from the whole channel so that the last programme of
+	// the window ends when the next one starts, even outside the window.
+	selected := programmes[:0]
+	for _, programme := range programmes {
+		if config.Channel.IsNull() || programme.Channel == config.Channel.ValueString() {
+			selected = append(selected, programme)
+		}
+	}
+	complete, open := fillEPGStops(selected)
+
+	windowStart, _ := time.Parse(time.RFC3339, config.WindowStart.ValueString())
+	windowEnd, _ := time.Parse(time.RFC3339, config.WindowEnd.ValueString())
+	inWindow := func(programme epgProgramme) bool {
+		if !config.WindowStart.IsNull() && programme.Start.Before(windowStart) {
+			return false
+		}
+		return config.WindowEnd.IsNull() || programme.Start.Before(windowEnd)
+	}
+	for _, programme := range open {
+		if inWindow(programme) {
+			resp.Diagnostics.AddWarning(
+				"EPG Programme Without End",
+				fmt.Sprintf("The programme %q starting at %s has no stop time and is the last of its channel; it was skipped.", programme.Title, epgTime(programme.Start)),
+			)
+		}
+	}
+	windowed := complete[:0]
+	for _, programme := range complete {
+		if inWindow(programme) {
+			windowed = append(windowed, programme)
+		}
+	}
+
+	slots, unmapped := mapEPG(windowed, mapping)
+	if len(unmapped) > 0 {
+		lines := make([]string, 0, len(unmapped))
+		for _, programme := range unmapped {
+			lines = append(lines, fmt.Sprintf("  %s %q %v", epgTime(programme.Start), programme.Title, programme.Categories))
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("mapping"),
+			"Unmapped EPG Programmes",
+			fmt.Sprintf("%d programme(s) match no title or category of mapping and were left out of slots:\n%s", len(unmapped), strings.Join(lines, "\n")),
+		)
+	}
+	for _, pair := range epgOverlaps(slots) {
+		a, b := slots[pair[0]].Programme, slots[pair[1]].Programme
+		resp.Diagnostics.AddWarning(
+			"Overlapping EPG Programmes",
+			fmt.Sprintf("The programme %q (%s to %s) overlaps the programme %q starting at %s.",
+				a.Title, epgTime(a.Start), epgTime(a.Stop), b.Title, epgTime(b.Start)),
+		)
+	}
+
+	slotModels := make([]epgSlotModel, 0, len(slots))
+	for _, slot := range slots {
+		slotModels = append(slotModels, epgSlotModel{
+			Name:          types.StringValue(slot.Programme.Title),
+			StartTime:     types.StringValue(epgTime(slot.Programme.Start)),
+			EndTime:       types.StringValue(epgTime(slot.Programme.Stop)),
+			ReplacementID: types.Int64Value(slot.ReplacementID),
+		})
+	}
+	unmappedModels := make([]epgUnmappedModel, 0, len(unmapped))
+	for _, programme := range unmapped {
+		categories, diags := types.ListValueFrom(ctx, types.StringType, programme.Categories)
+		resp.Diagnostics.Append(diags...)
+		unmappedModels = append(unmappedModels, epgUnmappedModel{
+			Title:      types.StringValue(programme.Title),
+			Categories: categories,
+			StartTime:  types.StringValue(epgTime(programme.Start)),
+			EndTime:    types.StringValue(epgTime(programme.Stop)),
+		})
+	}
+
+	var diags diag.Diagnostics
+	config.Slots, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: epgSlotAttrTypes}, slotModels)
+	resp.Diagnostics.Append(diags...)
+	config.Unmapped, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: epgUnmappedAttrTypes}, unmappedModels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+var epgSlotAttrTypes = map[string]attr.Type{
+	"name":           types.StringType,
+	"start_time":     types.StringType,
+	"end_time":       types.StringType,
+	"replacement_id": types.Int64Type,
+}
+
+var epgUnmappedAttrTypes = map[string]attr.Type{
+	"title":      types.StringType,
+	"categories": types.ListType{ElemType: types.StringType},
+	"start_time": types.StringType,
+	"end_time":   types.StringType,
+}
+
+// epgSlotsDataSourceModel maps the schema of the EPG slots data source.
+type epgSlotsDataSourceModel struct {
+	Path        types.String `tfsdk:"path"`
+	Format      types.String `tfsdk:"format"`
+	Channel     types.String `tfsdk:"channel"`
+	WindowStart types.String `tfsdk:"window_start"`
+	WindowEnd   types.String `tfsdk:"window_end"`
+	Mapping     types.Map    `tfsdk:"mapping"`
+	Slots       types.List   `tfsdk:"slots"`
+	Unmapped    types.List   `tfsdk:"unmapped"`
+}
+
+type epgSlotModel struct {
+	Name          types.String `tfsdk:"name"`
+	StartTime     types.String `tfsdk:"start_time"`
+	EndTime       types.String `tfsdk:"end_time"`
+	ReplacementID types.Int64  `tfsdk:"replacement_id"`
+}
+
+type epgUnmappedModel struct {
+	Title      types.String `tfsdk:"title"`
+	Categories types.List   `tfsdk:"categories"`
+	StartTime  types.String `tfsdk:"start_time"`
+	EndTime    types.String `tfsdk:"end_time"`
+}
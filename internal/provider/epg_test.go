@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func readEPGFixture(t *testing.T, name, format string) []epgProgramme {
+	t.Helper()
+	programmes, err := readEPG("testdata/epg/"+name, format)
+	require.NoError(t, err)
+	return programmes
+}
+
+func TestParseXMLTVTime(t *testing.T) {
+	tests := map[string]string{
+		"20250601200000 +0200": "2025-06-01T18:00:00Z",
+		"20250601200000":       "2025-06-01T20:00:00Z",
+		"202506012000 -0100":   "2025-06-01T21:00:00Z",
+		" 202506012000 ":       "2025-06-01T20:00:00Z",
+	}
+	for value, expect := range tests {
+		got, err := parseXMLTVTime(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expect, epgTime(got), value)
+	}
+
+	_, err := parseXMLTVTime("2025-06-01T20:00:00Z")
+	require.Error(t, err)
+}
+
+func TestParseXMLTV(t *testing.T) {
+	programmes := readEPGFixture(t, "schedule.xml", "xmltv")
+	require.Len(t, programmes, 5)
+
+	movie := programmes[1]
+	require.Equal(t, "movies.example.com", movie.Channel)
+	require.Equal(t, "The Big Movie", movie.Title)
+	require.Equal(t, []string{"Film", "Drama"}, movie.Categories)
+	require.Equal(t, "2025-06-01T19:00:00Z", epgTime(movie.Start))
+	require.Equal(t, "2025-06-01T21:00:00Z", epgTime(movie.Stop))
+
+	require.True(t, programmes[2].Stop.IsZero(), "Late Show has no stop time")
+
+	_, err := parseXMLTV(strings.NewReader(`<tv><programme start="tomorrow" channel="a"><title>x</title></programme></tv>`))
+	require.ErrorContains(t, err, `programme 1 ("x"): start`)
+}
+
+func TestParseEPGCSV(t *testing.T) {
+	programmes := readEPGFixture(t, "schedule.csv", "csv")
+	require.Len(t, programmes, 3)
+	require.Equal(t, "Evening News", programmes[0].Title)
+	require.Equal(t, []string{"News"}, programmes[0].Categories)
+	require.Empty(t, programmes[2].Categories)
+
+	programmes, err := parseEPGCSV(strings.NewReader("Title,Start_Time,End_Time,Category\nx,2025-06-01T10:00:00Z,2025-06-01T11:00:00Z,a | b\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, programmes[0].Categories)
+
+	_, err = parseEPGCSV(strings.NewReader("title,start_time\n"))
+	require.ErrorContains(t, err, `no "end_time" column`)
+
+	_, err = parseEPGCSV(strings.NewReader("title,start_time,end_time\nx,2025-06-01T10:00:00Z,later\n"))
+	require.ErrorContains(t, err, "line 2: end_time")
+}
+
+func TestFillEPGStops(t *testing.T) {
+	complete, open := fillEPGStops(readEPGFixture(t, "schedule.xml", "xmltv"))
+	require.Len(t, complete, 5)
+	require.Empty(t, open)
+
+	// Sorted by start, with the Late Show ending when the documentary starts.
+	require.Equal(t, "Cartoons", complete[0].Title)
+	require.Equal(t, "Late Show", complete[3].Title)
+	require.Equal(t, "2025-06-01T21:00:00Z", epgTime(complete[3].Stop))
+
+	// The next programme of another channel does not end a programme.
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	complete, open = fillEPGStops([]epgProgramme{
+		{Channel: "b", Title: "other", Start: start.Add(time.Hour), Stop: start.Add(2 * time.Hour)},
+		{Channel: "a", Title: "last", Start: start},
+	})
+	require.Len(t, complete, 1)
+	require.Len(t, open, 1)
+	require.Equal(t, "last", open[0].Title)
+}
+
+func TestMapEPG(t *testing.T) {
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	programmes := []epgProgramme{
+		{Title: "News", Categories: []string{"Film"}, Start: start},
+		{Title: "Movie", Categories: []string{"Drama", "Film"}, Start: start},
+		{Title: "Quiz", Categories: []string{"Games"}, Start: start},
+	}
+	slots, unmapped := mapEPG(programmes, map[string]int64{"News": 1, "Film": 2})
+
+	require.Len(t, slots, 2)
+	require.Equal(t, int64(1), slots[0].ReplacementID, "title wins over category")
+	require.Equal(t, int64(2), slots[1].ReplacementID, "first mapped category")
+	require.Len(t, unmapped, 1)
+	require.Equal(t, "Quiz", unmapped[0].Title)
+}
+
+func TestEPGOverlaps(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2025, 6, 1, hour, 0, 0, 0, time.UTC) }
+	slots := []epgSlot{
+		{Programme: epgProgramme{Start: at(10), Stop: at(12)}},
+		{Programme: epgProgramme{Start: at(11), Stop: at(12)}},
+		{Programme: epgProgramme{Start: at(12), Stop: at(13)}},
+	}
+	require.Equal(t, [][2]int{{0, 1}}, epgOverlaps(slots))
+}
+
+func TestEPGSlotsDataSource_XMLTV(t *testing.T) {
+	testUnitPreCheck(t)
+
+	dataSourceName := "data.bpkio_epg_slots.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "bpkio" {
+  api_key = "fake"
+}
+
+data "bpkio_epg_slots" "test" {
+  path    = "testdata/epg/schedule.xml"
+  channel = "movies.example.com"
+
+  mapping = {
+    "Evening News" = 11
+    "Film"         = 12
+    "Talk"         = 13
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "format", "xmltv"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.0.name", "Evening News"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.0.replacement_id", "11"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.1.start_time", "2025-06-01T19:00:00Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.1.end_time", "2025-06-01T21:00:00Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.2.name", "Late Show"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.2.end_time", "2025-06-01T21:00:00Z"),
+					resource.TestCheckResourceAttr(dataSourceName, "unmapped.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "unmapped.0.title", "Night Documentary"),
+				),
+			},
+		},
+	})
+}
+
+func TestEPGSlotsDataSource_CSVWindow(t *testing.T) {
+	testUnitPreCheck(t)
+
+	dataSourceName := "data.bpkio_epg_slots.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "bpkio" {
+  api_key = "fake"
+}
+
+data "bpkio_epg_slots" "test" {
+  path         = "testdata/epg/schedule.csv"
+  window_start = "2025-06-01T19:00:00Z"
+
+  mapping = {
+    "Film" = 12
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "format", "csv"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "slots.0.name", "The Big Movie"),
+					resource.TestCheckResourceAttr(dataSourceName, "unmapped.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "unmapped.0.title", "Unknown Programme"),
+					resource.TestCheckResourceAttr(dataSourceName, "unmapped.0.categories.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestEPGSlotsDataSource_MissingFile(t *testing.T) {
+	testUnitPreCheck(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "bpkio" {
+  api_key = "fake"
+}
+
+data "bpkio_epg_slots" "test" {
+  path    = "testdata/epg/missing.xml"
+  mapping = {}
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to Read EPG File`),
+			},
+		},
+	})
+}
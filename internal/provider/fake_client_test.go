@@ -0,0 +1,1192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+)
+
+// Ensure the fake satisfies the interface used by the provider.
+var _ apiClient = &fakeClient{}
+
+// fakeClient is a stateful in-memory implementation of apiClient. Objects get
+// increasing IDs, references between them are checked like the API does, and
+// missing objects are reported as 404s so that the provider behaves exactly
+// as against a real tenant.
+type fakeClient struct {
+	mu     sync.Mutex
+	nextID uint
+
+	sources             map[uint]broadpeakio.Source
+	adServers           map[uint]broadpeakio.AdServer
+	adInsertions        map[uint]apiAdInsertion
+	transcodingProfiles map[uint]broadpeakio.TranscodingProfile
+
+	// restSources holds the sources that the provider manages through
+	// bpkioClient.do rather than the SDK.
+	restSources map[uint]apiSource
+
+	contentReplacements map[uint]apiContentReplacement
+	virtualChannels     map[uint]apiVirtualChannel
+
+	slots map[fakeSlotKey]apiSlot
+}
+
+// fakeSlotKey identifies a slot by the type and ID of its service and its
+// own ID.
+type fakeSlotKey struct {
+	serviceType string
+	serviceID   uint
+	id          uint
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		nextID:              1,
+		sources:             map[uint]broadpeakio.Source{},
+		adServers:           map[uint]broadpeakio.AdServer{},
+		adInsertions:        map[uint]apiAdInsertion{},
+		transcodingProfiles: map[uint]broadpeakio.TranscodingProfile{},
+		restSources:         map[uint]apiSource{},
+		contentReplacements: map[uint]apiContentReplacement{},
+		virtualChannels:     map[uint]apiVirtualChannel{},
+		slots:               map[fakeSlotKey]apiSlot{},
+	}
+}
+
+// addTranscodingProfile seeds a transcoding profile, as if it had been
+// created outside of Terraform.
+func (f *fakeClient) addTranscodingProfile(name, content string) broadpeakio.TranscodingProfile {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID()
+	profile := broadpeakio.TranscodingProfile{
+		Id:         id,
+		Name:       name,
+		Content:    content,
+		InternalId: fmt.Sprintf("fake-%d", id),
+	}
+	f.transcodingProfiles[id] = profile
+	return profile
+}
+
+func (f *fakeClient) newID() uint {
+	id := f.nextID
+	f.nextID++
+	return id
+}
+
+func fakeNotFound(kind string, id uint) error {
+	return &apiError{StatusCode: http.StatusNotFound, Body: fmt.Sprintf(`{"message":"%s %d does not exist"}`, kind, id)}
+}
+
+func fakeValidationError(messages ...string) error {
+	return &apiError{StatusCode: http.StatusBadRequest, Body: fmt.Sprintf(`{"message":["%s"]}`, strings.Join(messages, `","`))}
+}
+
+func fakeConflict(kind string, id uint) error {
+	return &apiError{StatusCode: http.StatusConflict, Body: fmt.Sprintf(`{"message":"%s %d is used by a service"}`, kind, id)}
+}
+
+// inUse reports whether a service or a slot references the source or ad
+// server with the given ID, which the API refuses to delete. The caller
+// holds f.mu.
+func (f *fakeClient) inUse(id uint) bool {
+	var refs []uint
+	for _, s := range f.adInsertions {
+		refs = append(refs, s.Source.Id, s.LiveAdReplacement.AdServer.Id, s.LiveAdReplacement.GapFiller.Id,
+			s.LiveAdPreRoll.AdServer.Id, s.VodAdInsertion.AdServer.Id)
+	}
+	for _, s := range f.contentReplacements {
+		refs = append(refs, s.Source.Id, s.Replacement.Id)
+	}
+	for _, s := range f.virtualChannels {
+		refs = append(refs, s.BaseLive.Id)
+		if s.AdBreakInsertion != nil && s.AdBreakInsertion.AdServer != nil {
+			refs = append(refs, s.AdBreakInsertion.AdServer.Id)
+		}
+		if s.AdBreakInsertion != nil && s.AdBreakInsertion.GapFiller != nil {
+			refs = append(refs, s.AdBreakInsertion.GapFiller.Id)
+		}
+		if s.LivePreRoll != nil && s.LivePreRoll.AdServer != nil {
+			refs = append(refs, s.LivePreRoll.AdServer.Id)
+		}
+	}
+	for _, slot := range f.slots {
+		refs = append(refs, slot.Replacement.Id)
+	}
+	return slices.Contains(refs, id)
+}
+
+func fakeFormat(url string) string {
+	switch {
+	case strings.Contains(url, ".mpd"):
+		return "DASH"
+	case strings.HasSuffix(url, ".mp4"):
+		return "MP4"
+	default:
+		return "HLS"
+	}
+}
+
+func (f *fakeClient) getSource(sourceType string, id uint) (broadpeakio.Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source, ok := f.sources[id]
+	if !ok || source.Type != sourceType {
+		return broadpeakio.Source{}, fakeNotFound(sourceType, id)
+	}
+	return source, nil
+}
+
+func (f *fakeClient) deleteSource(sourceType string, id uint) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source, ok := f.sources[id]
+	if !ok || source.Type != sourceType {
+		return "", fakeNotFound(sourceType, id)
+	}
+	if f.inUse(id) {
+		return "", fakeConflict(sourceType, id)
+	}
+	delete(f.sources, id)
+	return "", nil
+}
+
+// Live sources.
+
+func (f *fakeClient) CreateLive(input broadpeakio.LiveInput) (broadpeakio.Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source := broadpeakio.Source{
+		Id:          f.newID(),
+		Type:        "live",
+		Name:        input.Name,
+		Url:         input.Url,
+		Description: input.Description,
+		MultiPeriod: input.MultiPeriod,
+		Format:      fakeFormat(input.Url),
+		Origin:      input.Origin,
+	}
+	f.sources[source.Id] = source
+	return source, nil
+}
+
+func (f *fakeClient) GetLive(id uint) (broadpeakio.Source, error) {
+	return f.getSource("live", id)
+}
+
+func (f *fakeClient) UpdateLive(id uint, input broadpeakio.LiveInput) (broadpeakio.Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source, ok := f.sources[id]
+	if !ok || source.Type != "live" {
+		return broadpeakio.Source{}, fakeNotFound("live", id)
+	}
+	source.Name = input.Name
+	source.Url = input.Url
+	source.Description = input.Description
+	source.MultiPeriod = input.MultiPeriod
+	source.Format = fakeFormat(input.Url)
+	source.Origin = input.Origin
+	f.sources[id] = source
+	return source, nil
+}
+
+func (f *fakeClient) DeleteLive(id uint) (string, error) {
+	return f.deleteSource("live", id)
+}
+
+// Slate sources.
+
+func (f *fakeClient) CreateSlate(input broadpeakio.SlateInput) (broadpeakio.Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source := broadpeakio.Source{
+		Id:          f.newID(),
+		Type:        "slate",
+		Name:        input.Name,
+		Url:         input.Url,
+		Description: input.Description,
+	}
+	f.sources[source.Id] = source
+	return source, nil
+}
+
+func (f *fakeClient) GetSlate(id uint) (broadpeakio.Source, error) {
+	return f.getSource("slate", id)
+}
+
+func (f *fakeClient) UpdateSlate(id uint, input broadpeakio.SlateInput) (broadpeakio.Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source, ok := f.sources[id]
+	if !ok || source.Type != "slate" {
+		return broadpeakio.Source{}, fakeNotFound("slate", id)
+	}
+	source.Name = input.Name
+	source.Url = input.Url
+	source.Description = input.Description
+	f.sources[id] = source
+	return source, nil
+}
+
+func (f *fakeClient) DeleteSlate(id uint) (string, error) {
+	return f.deleteSource("slate", id)
+}
+
+// Sources managed through direct REST calls.
+
+func fakeValidateSource(sourceType string, input apiSource) []string {
+	var messages []string
+	if input.Name == "" {
+		messages = append(messages, "name should not be empty")
+	}
+	if sourceType == "asset-catalog" && input.AssetSample == "" {
+		messages = append(messages, "assetSample should not be empty")
+	}
+	return messages
+}
+
+func (f *fakeClient) createRESTSource(sourceType string, input apiSource) (apiSource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if messages := fakeValidateSource(sourceType, input); len(messages) > 0 {
+		return apiSource{}, fakeValidationError(messages...)
+	}
+	input.Id = f.newID()
+	input.Type = sourceType
+	if sourceType == "asset" {
+		input.Format = fakeFormat(input.Url)
+	}
+	f.restSources[input.Id] = input
+	return input, nil
+}
+
+func (f *fakeClient) getRESTSource(sourceType string, id uint) (apiSource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source, ok := f.restSources[id]
+	if !ok || source.Type != sourceType {
+		return apiSource{}, fakeNotFound(sourceType, id)
+	}
+	return source, nil
+}
+
+func (f *fakeClient) updateRESTSource(sourceType string, id uint, input apiSource) (apiSource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source, ok := f.restSources[id]
+	if !ok || source.Type != sourceType {
+		return apiSource{}, fakeNotFound(sourceType, id)
+	}
+	if messages := fakeValidateSource(sourceType, input); len(messages) > 0 {
+		return apiSource{}, fakeValidationError(messages...)
+	}
+	input.Id = id
+	input.Type = sourceType
+	if sourceType == "asset" {
+		input.Format = fakeFormat(input.Url)
+	}
+	f.restSources[id] = input
+	return input, nil
+}
+
+func (f *fakeClient) deleteRESTSource(sourceType string, id uint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	source, ok := f.restSources[id]
+	if !ok || source.Type != sourceType {
+		return fakeNotFound(sourceType, id)
+	}
+	if f.inUse(id) {
+		return fakeConflict(sourceType, id)
+	}
+	delete(f.restSources, id)
+	return nil
+}
+
+// Asset sources.
+
+func (f *fakeClient) CreateAsset(_ context.Context, input apiSource) (apiSource, error) {
+	return f.createRESTSource("asset", input)
+}
+
+func (f *fakeClient) GetAsset(_ context.Context, id uint) (apiSource, error) {
+	return f.getRESTSource("asset", id)
+}
+
+func (f *fakeClient) UpdateAsset(_ context.Context, id uint, input apiSource) (apiSource, error) {
+	return f.updateRESTSource("asset", id, input)
+}
+
+func (f *fakeClient) DeleteAsset(_ context.Context, id uint) error {
+	return f.deleteRESTSource("asset", id)
+}
+
+// Asset catalog sources.
+
+func (f *fakeClient) CreateAssetCatalog(_ context.Context, input apiSource) (apiSource, error) {
+	return f.createRESTSource("asset-catalog", input)
+}
+
+func (f *fakeClient) GetAssetCatalog(_ context.Context, id uint) (apiSource, error) {
+	return f.getRESTSource("asset-catalog", id)
+}
+
+func (f *fakeClient) UpdateAssetCatalog(_ context.Context, id uint, input apiSource) (apiSource, error) {
+	return f.updateRESTSource("asset-catalog", id, input)
+}
+
+func (f *fakeClient) DeleteAssetCatalog(_ context.Context, id uint) error {
+	return f.deleteRESTSource("asset-catalog", id)
+}
+
+// Origin sources.
+
+func (f *fakeClient) CreateOrigin(_ context.Context, input apiSource) (apiSource, error) {
+	return f.createRESTSource("origin", input)
+}
+
+func (f *fakeClient) GetOrigin(_ context.Context, id uint) (apiSource, error) {
+	return f.getRESTSource("origin", id)
+}
+
+func (f *fakeClient) UpdateOrigin(_ context.Context, id uint, input apiSource) (apiSource, error) {
+	return f.updateRESTSource("origin", id, input)
+}
+
+func (f *fakeClient) DeleteOrigin(_ context.Context, id uint) error {
+	return f.deleteRESTSource("origin", id)
+}
+
+// Ad servers.
+
+func (f *fakeClient) CreateAdServer(input broadpeakio.AdServerInput) (broadpeakio.AdServer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	adServer := broadpeakio.AdServer{
+		Id:              f.newID(),
+		Type:            "ad-server",
+		Name:            input.Name,
+		Description:     input.Description,
+		Url:             input.Url,
+		Queries:         input.Queries,
+		QueryParameters: input.QueryParameters,
+	}
+	f.adServers[adServer.Id] = adServer
+	return adServer, nil
+}
+
+func (f *fakeClient) GetAdServer(id uint) (broadpeakio.AdServer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	adServer, ok := f.adServers[id]
+	if !ok {
+		return broadpeakio.AdServer{}, fakeNotFound("ad-server", id)
+	}
+	return adServer, nil
+}
+
+func (f *fakeClient) UpdateAdServer(id uint, input broadpeakio.AdServerInput) (broadpeakio.AdServer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	adServer, ok := f.adServers[id]
+	if !ok {
+		return broadpeakio.AdServer{}, fakeNotFound("ad-server", id)
+	}
+	adServer.Name = input.Name
+	adServer.Description = input.Description
+	adServer.Url = input.Url
+	adServer.Queries = input.Queries
+	adServer.QueryParameters = input.QueryParameters
+	f.adServers[id] = adServer
+	return adServer, nil
+}
+
+func (f *fakeClient) DeleteAdServer(id uint) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.adServers[id]; !ok {
+		return "", fakeNotFound("ad-server", id)
+	}
+	if f.inUse(id) {
+		return "", fakeConflict("ad-server", id)
+	}
+	delete(f.adServers, id)
+	return "", nil
+}
+
+// Ad insertion services.
+
+// resolveAdInsertion expands the references of input into the objects they
+// point at, as the API does in its responses. The caller holds f.mu.
+func (f *fakeClient) resolveAdInsertion(service *apiAdInsertion, input apiAdInsertionInput) error {
+	var problems []string
+
+	service.Name = input.Name
+	service.Tags = input.Tags
+	service.EnableAdTranscoding = input.EnableAdTranscoding
+	service.Source = broadpeakio.Source{}
+	service.TranscodingProfile = broadpeakio.TranscodingProfile{}
+	service.LiveAdPreRoll = broadpeakio.LiveAdPreRollOutput{}
+	service.LiveAdReplacement = broadpeakio.LiveAdReplacementOutput{}
+	service.VodAdInsertion = apiVodAdInsertion{}
+	service.ServerSideAdTracking.Enable = false
+	service.ServerSideAdTracking.CheckAdMediaSegmentAvailability = false
+	service.AdvancedOptions.AuthorizationHeader.Name = ""
+	service.AdvancedOptions.AuthorizationHeader.Value = ""
+
+	if input.Source != nil {
+		source, ok := f.sources[input.Source.Id]
+		if !ok {
+			problems = append(problems, "source must reference an existing source")
+		}
+		service.Source = source
+	}
+	if input.TranscodingProfile != nil {
+		profile, ok := f.transcodingProfiles[input.TranscodingProfile.Id]
+		if !ok {
+			problems = append(problems, "transcodingProfile must reference an existing transcoding profile")
+		}
+		service.TranscodingProfile = profile
+	}
+	if input.LiveAdPreRoll != nil {
+		service.LiveAdPreRoll.MaxDuration = input.LiveAdPreRoll.MaxDuration
+		service.LiveAdPreRoll.Offset = input.LiveAdPreRoll.Offset
+		if input.LiveAdPreRoll.AdServer != nil {
+			adServer, ok := f.adServers[input.LiveAdPreRoll.AdServer.Id]
+			if !ok {
+				problems = append(problems, "liveAdPreRoll.adServer must reference an existing ad server")
+			}
+			service.LiveAdPreRoll.AdServer = adServer
+		}
+	}
+	if input.LiveAdReplacement != nil {
+		service.LiveAdReplacement.SpotAware = input.LiveAdReplacement.SpotAware
+		if input.LiveAdReplacement.AdServer != nil {
+			adServer, ok := f.adServers[input.LiveAdReplacement.AdServer.Id]
+			if !ok {
+				problems = append(problems, "liveAdReplacement.adServer must reference an existing ad server")
+			}
+			service.LiveAdReplacement.AdServer = adServer
+		}
+		if input.LiveAdReplacement.GapFiller != nil {
+			gapFiller, ok := f.sources[input.LiveAdReplacement.GapFiller.Id]
+			if !ok || gapFiller.Type != "slate" {
+				problems = append(problems, "liveAdReplacement.gapFiller must reference an existing slate")
+			}
+			service.LiveAdReplacement.GapFiller = broadpeakio.GapFiller{
+				Id:   gapFiller.Id,
+				Name: gapFiller.Name,
+				Type: gapFiller.Type,
+				Url:  gapFiller.Url,
+			}
+		}
+	}
+	if input.VodAdInsertion != nil {
+		if service.Source.Type == "live" {
+			problems = append(problems, "vodAdInsertion is not supported with a live source")
+		}
+		if input.VodAdInsertion.AdServer != nil {
+			adServer, ok := f.adServers[input.VodAdInsertion.AdServer.Id]
+			if !ok {
+				problems = append(problems, "vodAdInsertion.adServer must reference an existing ad server")
+			}
+			service.VodAdInsertion.AdServer = adServer
+		}
+	}
+	if input.ServerSideAdTracking != nil {
+		service.ServerSideAdTracking.Enable = input.ServerSideAdTracking.Enable
+		service.ServerSideAdTracking.CheckAdMediaSegmentAvailability = input.ServerSideAdTracking.CheckAdMediaSegmentAvailability
+	}
+	if input.AdvancedOptions != nil {
+		service.AdvancedOptions.AuthorizationHeader.Name = input.AdvancedOptions.AuthorizationHeader.Name
+		service.AdvancedOptions.AuthorizationHeader.Value = input.AdvancedOptions.AuthorizationHeader.Value
+	}
+
+	if len(problems) > 0 {
+		return fakeValidationError(problems...)
+	}
+	return nil
+}
+
+func (f *fakeClient) CreateAdInsertion(_ context.Context, input apiAdInsertionInput) (apiAdInsertion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	service := apiAdInsertion{AdInsertionOutput: broadpeakio.AdInsertionOutput{
+		Type:         "ad-insertion",
+		State:        "enabled",
+		CreationDate: now,
+		UpdateDate:   now,
+	}}
+	if err := f.resolveAdInsertion(&service, input); err != nil {
+		return apiAdInsertion{}, err
+	}
+	service.Id = f.newID()
+	service.Url = fmt.Sprintf("https://stream.broadpeak.io/fake%d/", service.Id)
+	f.adInsertions[service.Id] = service
+	return service, nil
+}
+
+func (f *fakeClient) GetAdInsertion(_ context.Context, id uint) (apiAdInsertion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	service, ok := f.adInsertions[id]
+	if !ok {
+		return apiAdInsertion{}, fakeNotFound("ad-insertion", id)
+	}
+	return service, nil
+}
+
+func (f *fakeClient) SetAdInsertionState(_ context.Context, id uint, state string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	service, ok := f.adInsertions[id]
+	if !ok {
+		return fakeNotFound("ad-insertion", id)
+	}
+	if _, ok := serviceStateActions[state]; !ok {
+		return fakeValidationError(fmt.Sprintf("state %s is not supported", state))
+	}
+	service.State = state
+	f.adInsertions[id] = service
+	return nil
+}
+
+func (f *fakeClient) UpdateAdInsertion(_ context.Context, id uint, input apiAdInsertionInput) (apiAdInsertion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	service, ok := f.adInsertions[id]
+	if !ok {
+		return apiAdInsertion{}, fakeNotFound("ad-insertion", id)
+	}
+	if err := f.resolveAdInsertion(&service, input); err != nil {
+		return apiAdInsertion{}, err
+	}
+	service.UpdateDate = time.Now().UTC().Format(time.RFC3339)
+	f.adInsertions[id] = service
+	return service, nil
+}
+
+func (f *fakeClient) DeleteAdInsertion(id uint) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.adInsertions[id]; !ok {
+		return "", fakeNotFound("ad-insertion", id)
+	}
+	delete(f.adInsertions, id)
+	return "", nil
+}
+
+// Listings.
+
+// page returns the [offset, offset+limit) window of items sorted by ID.
+func page[T any](items map[uint]T, offset, limit int) []T {
+	ids := make([]uint, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	out := []T{}
+	for i := offset; i < len(ids) && i < offset+limit; i++ {
+		out = append(out, items[ids[i]])
+	}
+	return out
+}
+
+func (f *fakeClient) GetAllSources(offset int, limit int) ([]broadpeakio.Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	all := make(map[uint]broadpeakio.Source, len(f.sources)+len(f.adServers))
+	for id, source := range f.sources {
+		all[id] = source
+	}
+	for id, adServer := range f.adServers {
+		all[id] = broadpeakio.Source{
+			Id:          adServer.Id,
+			Name:        adServer.Name,
+			Type:        adServer.Type,
+			Url:         adServer.Url,
+			Description: adServer.Description,
+		}
+	}
+	for id, source := range f.restSources {
+		all[id] = broadpeakio.Source{
+			Id:          source.Id,
+			Name:        source.Name,
+			Type:        source.Type,
+			Url:         source.Url,
+			Description: source.Description,
+			Format:      source.Format,
+		}
+	}
+	return page(all, offset, limit), nil
+}
+
+// Content replacement services.
+
+// sourceRef returns the source with the given ID as expanded in services, if
+// it has one of the given types. The caller holds f.mu.
+func (f *fakeClient) sourceRef(id uint, sourceTypes ...string) (apiSourceRef, bool) {
+	ref := apiSourceRef{Id: id}
+	if source, ok := f.sources[id]; ok {
+		ref = apiSourceRef{Id: id, Name: source.Name, Type: source.Type, Url: source.Url}
+	} else if source, ok := f.restSources[id]; ok {
+		ref = apiSourceRef{Id: id, Name: source.Name, Type: source.Type, Url: source.Url}
+	} else if adServer, ok := f.adServers[id]; ok {
+		ref = apiSourceRef{Id: id, Name: adServer.Name, Type: adServer.Type, Url: adServer.Url}
+	}
+	for _, sourceType := range sourceTypes {
+		if ref.Type == sourceType {
+			return ref, true
+		}
+	}
+	return ref, false
+}
+
+// transcodingProfileRef returns the transcoding profile with the given ID as
+// expanded in services. The caller holds f.mu.
+func (f *fakeClient) transcodingProfileRef(id uint) (apiTranscodingProfileRef, bool) {
+	profile, ok := f.transcodingProfiles[id]
+	return apiTranscodingProfileRef{
+		Id:         id,
+		Name:       profile.Name,
+		InternalId: profile.InternalId,
+		Content:    profile.Content,
+	}, ok
+}
+
+// resolveContentReplacement applies input to service, expanding its
+// references. The caller holds f.mu.
+func (f *fakeClient) resolveContentReplacement(service *apiContentReplacement, input apiContentReplacementInput) error {
+	var problems []string
+	var ok bool
+
+	service.Name = input.Name
+	service.Tags = input.Tags
+	service.TranscodingProfile = apiTranscodingProfileRef{}
+
+	if service.Source, ok = f.sourceRef(input.Source.Id, "live"); !ok {
+		problems = append(problems, "source must reference an existing live source")
+	}
+	if service.Replacement, ok = f.sourceRef(input.Replacement.Id, "slate", "asset"); !ok {
+		problems = append(problems, "replacement must reference an existing slate or asset")
+	}
+	if input.TranscodingProfile != nil {
+		if service.TranscodingProfile, ok = f.transcodingProfileRef(input.TranscodingProfile.Id); !ok {
+			problems = append(problems, "transcodingProfile must reference an existing transcoding profile")
+		}
+	}
+
+	if len(problems) > 0 {
+		return fakeValidationError(problems...)
+	}
+	return nil
+}
+
+func (f *fakeClient) CreateContentReplacement(_ context.Context, input apiContentReplacementInput) (apiContentReplacement, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	service := apiContentReplacement{
+		Type:         "content-replacement",
+		State:        "enabled",
+		CreationDate: now,
+		UpdateDate:   now,
+	}
+	if err := f.resolveContentReplacement(&service, input); err != nil {
+		return apiContentReplacement{}, err
+	}
+	service.Id = f.newID()
+	service.Url = fmt.Sprintf("https://stream.broadpeak.io/fake%d/", service.Id)
+	f.contentReplacements[service.Id] = service
+	return service, nil
+}
+
+func (f *fakeClient) GetContentReplacement(_ context.Context, id uint) (apiContentReplacement, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	service, ok := f.contentReplacements[id]
+	if !ok {
+		return apiContentReplacement{}, fakeNotFound("content-replacement", id)
+	}
+	return service, nil
+}
+
+func (f *fakeClient) UpdateContentReplacement(_ context.Context, id uint, input apiContentReplacementInput) (apiContentReplacement, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	service, ok := f.contentReplacements[id]
+	if !ok {
+		return apiContentReplacement{}, fakeNotFound("content-replacement", id)
+	}
+	if err := f.resolveContentReplacement(&service, input); err != nil {
+		return apiContentReplacement{}, err
+	}
+	service.UpdateDate = time.Now().UTC().Format(time.RFC3339)
+	f.contentReplacements[id] = service
+	return service, nil
+}
+
+func (f *fakeClient) DeleteContentReplacement(_ context.Context, id uint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.contentReplacements[id]; !ok {
+		return fakeNotFound("content-replacement", id)
+	}
+	delete(f.contentReplacements, id)
+	return nil
+}
+
+// Virtual channel services.
+
+// optionalSourceRef is sourceRef for references that may be missing, which
+// are always valid.
+func (f *fakeClient) optionalSourceRef(r *apiRef, sourceTypes ...string) (*apiSourceRef, bool) {
+	if r == nil {
+		return nil, true
+	}
+	ref, ok := f.sourceRef(r.Id, sourceTypes...)
+	return &ref, ok
+}
+
+// resolveVirtualChannel applies input to service, expanding its references.
+// The caller holds f.mu.
+func (f *fakeClient) resolveVirtualChannel(service *apiVirtualChannel, input apiVirtualChannelInput) error {
+	var problems []string
+	var ok bool
+
+	service.Name = input.Name
+	service.Tags = input.Tags
+	service.EnableAdTranscoding = input.EnableAdTranscoding
+	service.TranscodingProfile = apiTranscodingProfileRef{}
+	service.AdBreakInsertion = nil
+	service.LivePreRoll = nil
+
+	if service.BaseLive, ok = f.sourceRef(input.BaseLive.Id, "live"); !ok {
+		problems = append(problems, "baseLive must reference an existing live source")
+	}
+	if input.TranscodingProfile != nil {
+		if service.TranscodingProfile, ok = f.transcodingProfileRef(input.TranscodingProfile.Id); !ok {
+			problems = append(problems, "transcodingProfile must reference an existing transcoding profile")
+		}
+	}
+	if p := input.AdBreakInsertion; p != nil {
+		service.AdBreakInsertion = &apiAdBreakInsertion{}
+		if service.AdBreakInsertion.AdServer, ok = f.optionalSourceRef(p.AdServer, "ad-server"); !ok {
+			problems = append(problems, "adBreakInsertion.adServer must reference an existing ad server")
+		}
+		if service.AdBreakInsertion.GapFiller, ok = f.optionalSourceRef(p.GapFiller, "slate"); !ok {
+			problems = append(problems, "adBreakInsertion.gapFiller must reference an existing slate")
+		}
+	}
+	if p := input.LivePreRoll; p != nil {
+		service.LivePreRoll = &apiLivePreRoll{MaxDuration: p.MaxDuration, Offset: p.Offset}
+		if service.LivePreRoll.AdServer, ok = f.optionalSourceRef(p.AdServer, "ad-server"); !ok {
+			problems = append(problems, "livePreRoll.adServer must reference an existing ad server")
+		}
+	}
+
+	if len(problems) > 0 {
+		return fakeValidationError(problems...)
+	}
+	return nil
+}
+
+func (f *fakeClient) CreateVirtualChannel(_ context.Context, input apiVirtualChannelInput) (apiVirtualChannel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	service := apiVirtualChannel{
+		Type:         "virtual-channel",
+		State:        "enabled",
+		CreationDate: now,
+		UpdateDate:   now,
+	}
+	if err := f.resolveVirtualChannel(&service, input); err != nil {
+		return apiVirtualChannel{}, err
+	}
+	service.Id = f.newID()
+	service.Url = fmt.Sprintf("https://stream.broadpeak.io/fake%d/", service.Id)
+	f.virtualChannels[service.Id] = service
+	return service, nil
+}
+
+func (f *fakeClient) GetVirtualChannel(_ context.Context, id uint) (apiVirtualChannel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	service, ok := f.virtualChannels[id]
+	if !ok {
+		return apiVirtualChannel{}, fakeNotFound("virtual-channel", id)
+	}
+	return service, nil
+}
+
+func (f *fakeClient) UpdateVirtualChannel(_ context.Context, id uint, input apiVirtualChannelInput) (apiVirtualChannel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	service, ok := f.virtualChannels[id]
+	if !ok {
+		return apiVirtualChannel{}, fakeNotFound("virtual-channel", id)
+	}
+	if err := f.resolveVirtualChannel(&service, input); err != nil {
+		return apiVirtualChannel{}, err
+	}
+	service.UpdateDate = time.Now().UTC().Format(time.RFC3339)
+	f.virtualChannels[id] = service
+	return service, nil
+}
+
+func (f *fakeClient) DeleteVirtualChannel(_ context.Context, id uint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.virtualChannels[id]; !ok {
+		return fakeNotFound("virtual-channel", id)
+	}
+	delete(f.virtualChannels, id)
+	return nil
+}
+
+func (f *fakeClient) GetAllServices(offset int, limit int) ([]broadpeakio.ServiceOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	all := make(map[uint]broadpeakio.ServiceOutput, len(f.adInsertions))
+	for id, service := range f.adInsertions {
+		all[id] = broadpeakio.ServiceOutput{
+			Id:              service.Id,
+			Name:            service.Name,
+			Type:            service.Type,
+			Url:             service.Url,
+			CreationDate:    service.CreationDate,
+			UpdateDate:      service.UpdateDate,
+			State:           service.State,
+			EnvironmentTags: service.Tags,
+		}
+	}
+	for id, service := range f.contentReplacements {
+		all[id] = broadpeakio.ServiceOutput{
+			Id:              service.Id,
+			Name:            service.Name,
+			Type:            service.Type,
+			Url:             service.Url,
+			CreationDate:    service.CreationDate,
+			UpdateDate:      service.UpdateDate,
+			State:           service.State,
+			EnvironmentTags: service.Tags,
+		}
+	}
+	for id, service := range f.virtualChannels {
+		all[id] = broadpeakio.ServiceOutput{
+			Id:              service.Id,
+			Name:            service.Name,
+			Type:            service.Type,
+			Url:             service.Url,
+			CreationDate:    service.CreationDate,
+			UpdateDate:      service.UpdateDate,
+			State:           service.State,
+			EnvironmentTags: service.Tags,
+		}
+	}
+	return page(all, offset, limit), nil
+}
+
+// Slots.
+
+// slotReplacementTypes lists the types of source that the slots of each type
+// of service can play.
+var slotReplacementTypes = map[string][]string{
+	"virtual-channel":     {"live", "asset", "slate"},
+	"content-replacement": {"slate", "asset"},
+}
+
+// hasService reports whether a service of the given type exists. The caller
+// holds f.mu.
+func (f *fakeClient) hasService(serviceType string, id uint) bool {
+	switch serviceType {
+	case "virtual-channel":
+		_, ok := f.virtualChannels[id]
+		return ok
+	case "content-replacement":
+		_, ok := f.contentReplacements[id]
+		return ok
+	}
+	return false
+}
+
+// resolveSlot applies input to slot, computing the end time or duration
+// that was not given. The caller holds f.mu.
+func (f *fakeClient) resolveSlot(serviceType string, slot *apiSlot, input apiSlotInput) error {
+	var problems []string
+	var ok bool
+
+	slot.Name = input.Name
+	slot.Category = nil
+
+	start, err := time.Parse(time.RFC3339, input.StartTime)
+	if err != nil {
+		problems = append(problems, "startTime must be a valid ISO 8601 date string")
+	}
+	switch {
+	case input.EndTime != "":
+		end, err := time.Parse(time.RFC3339, input.EndTime)
+		if err != nil || !end.After(start) {
+			problems = append(problems, "endTime must be after startTime")
+		}
+		slot.Duration = uint(end.Sub(start) / time.Second)
+	case input.Duration > 0:
+		slot.Duration = input.Duration
+	default:
+		problems = append(problems, "either endTime or duration must be provided")
+	}
+	slot.StartTime = start.UTC().Format(time.RFC3339)
+	slot.EndTime = start.Add(time.Duration(slot.Duration) * time.Second).UTC().Format(time.RFC3339)
+
+	if slot.Replacement, ok = f.sourceRef(input.Replacement.Id, slotReplacementTypes[serviceType]...); !ok {
+		problems = append(problems, "replacement must reference an existing "+strings.Join(slotReplacementTypes[serviceType], ", ")+" source")
+	}
+	if input.Category != nil {
+		slot.Category = &apiCategoryRef{Id: input.Category.Id, Name: fmt.Sprintf("category-%d", input.Category.Id)}
+	}
+
+	if len(problems) > 0 {
+		return fakeValidationError(problems...)
+	}
+	return nil
+}
+
+func (f *fakeClient) createSlot(serviceType string, serviceID uint, input apiSlotInput) (apiSlot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.hasService(serviceType, serviceID) {
+		return apiSlot{}, fakeNotFound(serviceType, serviceID)
+	}
+	var slot apiSlot
+	if err := f.resolveSlot(serviceType, &slot, input); err != nil {
+		return apiSlot{}, err
+	}
+	slot.Id = f.newID()
+	f.slots[fakeSlotKey{serviceType, serviceID, slot.Id}] = slot
+	return slot, nil
+}
+
+func (f *fakeClient) getSlot(serviceType string, serviceID, id uint) (apiSlot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	slot, ok := f.slots[fakeSlotKey{serviceType, serviceID, id}]
+	if !ok {
+		return apiSlot{}, fakeNotFound("slot", id)
+	}
+	return slot, nil
+}
+
+func (f *fakeClient) updateSlot(serviceType string, serviceID, id uint, input apiSlotInput) (apiSlot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := fakeSlotKey{serviceType, serviceID, id}
+	slot, ok := f.slots[key]
+	if !ok {
+		return apiSlot{}, fakeNotFound("slot", id)
+	}
+	if err := f.resolveSlot(serviceType, &slot, input); err != nil {
+		return apiSlot{}, err
+	}
+	f.slots[key] = slot
+	return slot, nil
+}
+
+// deleteSlot refuses to delete slots that are over, like the API.
+func (f *fakeClient) deleteSlot(serviceType string, serviceID, id uint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := fakeSlotKey{serviceType, serviceID, id}
+	slot, ok := f.slots[key]
+	if !ok {
+		return fakeNotFound("slot", id)
+	}
+	if end, _ := time.Parse(time.RFC3339, slot.EndTime); end.Before(time.Now()) {
+		return fakeValidationError("slots in the past cannot be deleted")
+	}
+	delete(f.slots, key)
+	return nil
+}
+
+// ListVirtualChannelSlots returns the slots starting in [from, to), ordered
+// by ID.
+func (f *fakeClient) ListVirtualChannelSlots(_ context.Context, serviceID uint, from, to string, offset, limit int) ([]apiSlot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.hasService("virtual-channel", serviceID) {
+		return nil, fakeNotFound("virtual-channel", serviceID)
+	}
+	start, errFrom := time.Parse(time.RFC3339, from)
+	end, errTo := time.Parse(time.RFC3339, to)
+	if errFrom != nil || errTo != nil {
+		return nil, fakeValidationError("from and to must be valid ISO 8601 date strings")
+	}
+
+	slots := map[uint]apiSlot{}
+	for key, slot := range f.slots {
+		if key.serviceType != "virtual-channel" || key.serviceID != serviceID {
+			continue
+		}
+		if t, _ := time.Parse(time.RFC3339, slot.StartTime); !t.Before(start) && t.Before(end) {
+			slots[key.id] = slot
+		}
+	}
+	return page(slots, offset, limit), nil
+}
+
+// endSlots moves all the slots one day back, as if they had ended.
+func (f *fakeClient) endSlots() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for key, slot := range f.slots {
+		end := time.Now().Add(-24 * time.Hour)
+		slot.EndTime = end.UTC().Format(time.RFC3339)
+		slot.StartTime = end.Add(-time.Duration(slot.Duration) * time.Second).UTC().Format(time.RFC3339)
+		f.slots[key] = slot
+	}
+}
+
+func (f *fakeClient) CreateVirtualChannelSlot(_ context.Context, serviceID uint, input apiSlotInput) (apiSlot, error) {
+	return f.createSlot("virtual-channel", serviceID, input)
+}
+
+func (f *fakeClient) GetVirtualChannelSlot(_ context.Context, serviceID, id uint) (apiSlot, error) {
+	return f.getSlot("virtual-channel", serviceID, id)
+}
+
+func (f *fakeClient) UpdateVirtualChannelSlot(_ context.Context, serviceID, id uint, input apiSlotInput) (apiSlot, error) {
+	return f.updateSlot("virtual-channel", serviceID, id, input)
+}
+
+func (f *fakeClient) DeleteVirtualChannelSlot(_ context.Context, serviceID, id uint) error {
+	return f.deleteSlot("virtual-channel", serviceID, id)
+}
+
+func (f *fakeClient) CreateContentReplacementSlot(_ context.Context, serviceID uint, input apiSlotInput) (apiSlot, error) {
+	return f.createSlot("content-replacement", serviceID, input)
+}
+
+func (f *fakeClient) GetContentReplacementSlot(_ context.Context, serviceID, id uint) (apiSlot, error) {
+	return f.getSlot("content-replacement", serviceID, id)
+}
+
+func (f *fakeClient) UpdateContentReplacementSlot(_ context.Context, serviceID, id uint, input apiSlotInput) (apiSlot, error) {
+	return f.updateSlot("content-replacement", serviceID, id, input)
+}
+
+func (f *fakeClient) DeleteContentReplacementSlot(_ context.Context, serviceID, id uint) error {
+	return f.deleteSlot("content-replacement", serviceID, id)
+}
+
+// Transcoding profiles.
+
+func (f *fakeClient) GetTranscodingProfile(id uint) (broadpeakio.TranscodingProfile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	profile, ok := f.transcodingProfiles[id]
+	if !ok {
+		return broadpeakio.TranscodingProfile{}, fakeNotFound("transcoding profile", id)
+	}
+	return profile, nil
+}
+
+func (f *fakeClient) GetAllTranscodingProfiles(offset int, limit int) ([]broadpeakio.TranscodingProfile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return page(f.transcodingProfiles, offset, limit), nil
+}
+
+func fakeTranscodingProfile(profile broadpeakio.TranscodingProfile) apiTranscodingProfile {
+	return apiTranscodingProfile{Id: profile.Id, Name: profile.Name, Content: profile.Content, InternalId: profile.InternalId}
+}
+
+func (f *fakeClient) CreateTranscodingProfile(_ context.Context, input apiTranscodingProfileInput) (apiTranscodingProfile, error) {
+	if !json.Valid([]byte(input.Content)) {
+		return apiTranscodingProfile{}, fakeValidationError("content must be a valid JSON string")
+	}
+	profile := f.addTranscodingProfile(input.Name, input.Content)
+	return fakeTranscodingProfile(profile), nil
+}
+
+func (f *fakeClient) UpdateTranscodingProfile(_ context.Context, id uint, input apiTranscodingProfileInput) (apiTranscodingProfile, error) {
+	if !json.Valid([]byte(input.Content)) {
+		return apiTranscodingProfile{}, fakeValidationError("content must be a valid JSON string")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	profile, ok := f.transcodingProfiles[id]
+	if !ok {
+		return apiTranscodingProfile{}, fakeNotFound("transcoding profile", id)
+	}
+	profile.Name = input.Name
+	profile.Content = input.Content
+	f.transcodingProfiles[id] = profile
+	return fakeTranscodingProfile(profile), nil
+}
+
+func (f *fakeClient) DeleteTranscodingProfile(_ context.Context, id uint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.transcodingProfiles[id]; !ok {
+		return fakeNotFound("transcoding profile", id)
+	}
+	delete(f.transcodingProfiles, id)
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = jsonStringType{}
+	_ basetypes.StringValuableWithSemanticEquals = jsonStringValue{}
+	_ xattr.ValidateableAttribute                = jsonStringValue{}
+)
+
+// jsonStringType is a string holding a JSON document, such as the content of
+// a transcoding profile. Its values compare semantically so that the API
+// re-serializing a document with another key order or whitespace is not a
+// diff.
+type jsonStringType struct {
+	basetypes.StringType
+}
+
+func (t jsonStringType) Equal(o attr.Type) bool {
+	other, ok := o.(jsonStringType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t jsonStringType) String() string {
+	return "jsonStringType"
+}
+
+func (t jsonStringType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return jsonStringValue{StringValue: in}, nil
+}
+
+func (t jsonStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return jsonStringValue{StringValue: stringValue}, nil
+}
+
+func (t jsonStringType) ValueType(_ context.Context) attr.Value {
+	return jsonStringValue{}
+}
+
+// jsonStringValue is a value of jsonStringType. The empty string is allowed
+// and stands for no document.
+type jsonStringValue struct {
+	basetypes.StringValue
+}
+
+func newJSONStringValue(value string) jsonStringValue {
+	return jsonStringValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func newJSONStringNull() jsonStringValue {
+	return jsonStringValue{StringValue: basetypes.NewStringNull()}
+}
+
+func (v jsonStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(jsonStringValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v jsonStringValue) Type(_ context.Context) attr.Type {
+	return jsonStringType{}
+}
+
+// StringSemanticEquals reports whether both values decode to the same JSON
+// document. Values that are not valid JSON only equal themselves.
+func (v jsonStringValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(jsonStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return jsonEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+// ValidateAttribute checks at plan time that a configured value is valid JSON.
+func (v jsonStringValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+		return
+	}
+
+	var document any
+	if err := json.Unmarshal([]byte(v.ValueString()), &document); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON String",
+			fmt.Sprintf("A string value was provided that is not valid JSON: %s. Use jsonencode to build the document from an HCL object.", err),
+		)
+	}
+}
+
+// jsonEqual reports whether a and b hold the same JSON document.
+func jsonEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var da, db any
+	if json.Unmarshal([]byte(a), &da) != nil || json.Unmarshal([]byte(b), &db) != nil {
+		return false
+	}
+	return reflect.DeepEqual(da, db)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONStringValue_SemanticEquals(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{name: "identical", a: `{"a":1}`, b: `{"a":1}`, equal: true},
+		{name: "key order", a: `{"a":1,"b":[1,2]}`, b: `{"b":[1,2],"a":1}`, equal: true},
+		{name: "whitespace", a: `{"a":{"b":"c"}}`, b: "{\n  \"a\": {\n    \"b\": \"c\"\n  }\n}", equal: true},
+		{name: "number forms", a: `{"a":1}`, b: `{"a":1.0}`, equal: true},
+		{name: "empty", a: "", b: "", equal: true},
+		{name: "different value", a: `{"a":1}`, b: `{"a":2}`},
+		{name: "array order", a: `[1,2]`, b: `[2,1]`},
+		{name: "empty and document", a: "", b: `{}`},
+		{name: "invalid", a: `{"a":1`, b: `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := newJSONStringValue(tt.a).StringSemanticEquals(context.Background(), newJSONStringValue(tt.b))
+			require.False(t, diags.HasError())
+			require.Equal(t, tt.equal, equal)
+		})
+	}
+
+	_, diags := newJSONStringValue("{}").StringSemanticEquals(context.Background(), basetypes.NewStringValue("{}"))
+	require.True(t, diags.HasError())
+}
+
+func TestJSONStringValue_ValidateAttribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   jsonStringValue
+		wantErr bool
+	}{
+		{name: "object", value: newJSONStringValue(`{"jobs":[]}`)},
+		{name: "empty", value: newJSONStringValue("")},
+		{name: "null", value: newJSONStringNull()},
+		{name: "unknown", value: jsonStringValue{StringValue: basetypes.NewStringUnknown()}},
+		{name: "truncated", value: newJSONStringValue(`{"jobs":[`), wantErr: true},
+		{name: "not json", value: newJSONStringValue("jobs"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp xattr.ValidateAttributeResponse
+			tt.value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("content")}, &resp)
+			require.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+func TestJSONStringType_ValueFromTerraform(t *testing.T) {
+	ctx := context.Background()
+
+	value, err := jsonStringType{}.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, `{"a":1}`))
+	require.NoError(t, err)
+	require.Equal(t, newJSONStringValue(`{"a":1}`), value)
+	require.True(t, value.Type(ctx).Equal(jsonStringType{}))
+
+	value, err = jsonStringType{}.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, nil))
+	require.NoError(t, err)
+	require.True(t, value.IsNull())
+}
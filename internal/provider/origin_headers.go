@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// customHeaderAttrTypes and originAttrTypes describe the `origin` attribute
+// shared by the sources that fetch content from an origin.
+var (
+	customHeaderAttrTypes = map[string]attr.Type{
+		"name":  types.StringType,
+		"value": types.StringType,
+	}
+	originAttrTypes = map[string]attr.Type{
+		"custom_headers": types.ListType{ElemType: types.ObjectType{AttrTypes: customHeaderAttrTypes}},
+	}
+)
+
+// originResourceAttribute is the `origin` attribute of a source resource;
+// kind names the source in the description, e.g. "source asset". Header
+// values are marked sensitive when sensitiveValues is set.
+func originResourceAttribute(kind string, sensitiveValues bool) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Attributes: map[string]schema.Attribute{
+			"custom_headers": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Headers added to the requests sent to the origin.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the custom header.",
+						},
+						"value": schema.StringAttribute{
+							Required:    true,
+							Sensitive:   sensitiveValues,
+							Description: "The value of the custom header.",
+						},
+					},
+				},
+			},
+		},
+		Optional:    true,
+		Description: fmt.Sprintf("The origin configuration for the %s.", kind),
+	}
+}
+
+// originDataSourceAttribute is the computed `origin` attribute of a source
+// data source, see originResourceAttribute.
+func originDataSourceAttribute(sensitiveValues bool) dsschema.SingleNestedAttribute {
+	return dsschema.SingleNestedAttribute{
+		Attributes: map[string]dsschema.Attribute{
+			"custom_headers": dsschema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: dsschema.NestedAttributeObject{
+					Attributes: map[string]dsschema.Attribute{
+						"name": dsschema.StringAttribute{
+							Computed: true,
+						},
+						"value": dsschema.StringAttribute{
+							Computed:  true,
+							Sensitive: sensitiveValues,
+						},
+					},
+				},
+			},
+		},
+		Computed: true,
+	}
+}
+
+// expandOrigin converts the `origin` attribute into its API representation.
+// A null or unknown origin has no custom headers.
+func expandOrigin(ctx context.Context, value types.Object) (apiOrigin, diag.Diagnostics) {
+	var out apiOrigin
+	if value.IsNull() || value.IsUnknown() {
+		return out, nil
+	}
+
+	var origin originModel
+	diags := value.As(ctx, &origin, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return out, diags
+	}
+
+	for _, h := range origin.CustomHeaders {
+		out.CustomHeaders = append(out.CustomHeaders, apiCustomHeader{
+			Name:  h.Name.ValueString(),
+			Value: h.Value.ValueString(),
+		})
+	}
+	return out, diags
+}
+
+// flattenOrigin converts an API origin into the `origin` attribute. An origin
+// without custom headers is null, like for bpkio_source_live.
+func flattenOrigin(origin apiOrigin) (types.Object, diag.Diagnostics) {
+	if len(origin.CustomHeaders) == 0 {
+		return types.ObjectNull(originAttrTypes), nil
+	}
+
+	var diags diag.Diagnostics
+	headers := make([]attr.Value, 0, len(origin.CustomHeaders))
+	for _, h := range origin.CustomHeaders {
+		header, d := types.ObjectValue(customHeaderAttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(h.Name),
+			"value": types.StringValue(h.Value),
+		})
+		diags.Append(d...)
+		headers = append(headers, header)
+	}
+	if diags.HasError() {
+		return types.ObjectNull(originAttrTypes), diags
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: customHeaderAttrTypes}, headers)
+	diags.Append(d...)
+	if diags.HasError() {
+		return types.ObjectNull(originAttrTypes), diags
+	}
+
+	value, d := types.ObjectValue(originAttrTypes, map[string]attr.Value{
+		"custom_headers": list,
+	})
+	diags.Append(d...)
+	return value, diags
+}
+
+// keepEmptyOrigin returns prior when the API reports no custom headers for an
+// origin that the configuration sets without any (`origin = {}`), so that the
+// state keeps matching the configuration.
+func keepEmptyOrigin(flattened, prior types.Object) types.Object {
+	if flattened.IsNull() && !prior.IsNull() && !prior.IsUnknown() {
+		return prior
+	}
+	return flattened
+}
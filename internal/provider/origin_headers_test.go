@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOriginRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	origin := apiOrigin{CustomHeaders: []apiCustomHeader{{Name: "X-Token", Value: "secret"}}}
+	value, diags := flattenOrigin(origin)
+	require.False(t, diags.HasError())
+
+	back, diags := expandOrigin(ctx, value)
+	require.False(t, diags.HasError())
+	require.Equal(t, origin, back)
+
+	empty, diags := flattenOrigin(apiOrigin{})
+	require.False(t, diags.HasError())
+	require.True(t, empty.IsNull())
+
+	back, diags = expandOrigin(ctx, types.ObjectUnknown(originAttrTypes))
+	require.False(t, diags.HasError())
+	require.Empty(t, back.CustomHeaders)
+}
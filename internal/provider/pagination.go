@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listPageSize is the number of items requested per call by list data
+// sources.
+const listPageSize = 200
+
+// maxResultsAttribute is the `max_results` attribute of list data sources.
+func maxResultsAttribute(items string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:    true,
+		Description: fmt.Sprintf("Maximum number of %s to return. All matching %s are returned when unset.", items, items),
+		Validators: []validator.Int64{
+			int64validator.AtLeast(1),
+		},
+	}
+}
+
+// listAll pages through a Broadpeak listing and returns the items for which
+// keep returns true (all of them when keep is nil). It stops after
+// maxResults items when maxResults is positive, and then reports whether
+// matching items were left out.
+//
+// Pages are read until one comes back empty, and the offset advances by the
+// number of items received, so a page size capped by the API below
+// listPageSize loses nothing. A page starting with the same item as the
+// previous one means the API ignored the offset; listAll then fails rather
+// than looping forever.
+func listAll[T any](fetch func(offset, limit int) ([]T, error), maxResults int64, keep func(T) bool) ([]T, bool, error) {
+	var items []T
+	var previous []T
+	for offset := 0; ; {
+		page, err := fetch(offset, listPageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(page) == 0 {
+			return items, false, nil
+		}
+		if len(previous) > 0 && reflect.DeepEqual(page[0], previous[0]) {
+			return nil, false, fmt.Errorf("listing did not advance at offset %d: the API returned the previous page again", offset)
+		}
+
+		for _, item := range page {
+			if keep != nil && !keep(item) {
+				continue
+			}
+			if maxResults > 0 && int64(len(items)) == maxResults {
+				return items, true, nil
+			}
+			items = append(items, item)
+		}
+
+		previous = page
+		offset += len(page)
+	}
+}
+
+// addTruncationWarning tells the user that a list data source returned only
+// maxResults items.
+func addTruncationWarning(diags *diag.Diagnostics, maxResults types.Int64, items string) {
+	diags.AddAttributeWarning(
+		path.Root("max_results"),
+		"Results Truncated",
+		fmt.Sprintf("Only the first %d %s were returned, more are available. Raise or remove max_results to get them all.", maxResults.ValueInt64(), items),
+	)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAll(t *testing.T) {
+	// 2.5 pages of items numbered from 0.
+	total := listPageSize*2 + listPageSize/2
+	var calls []int
+	fetch := func(offset, limit int) ([]int, error) {
+		calls = append(calls, offset)
+		var page []int
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, i)
+		}
+		return page, nil
+	}
+	even := func(i int) bool { return i%2 == 0 }
+
+	tests := []struct {
+		name          string
+		maxResults    int64
+		keep          func(int) bool
+		wantLen       int
+		wantTruncated bool
+		wantCalls     []int
+	}{
+		{name: "all pages", wantLen: total, wantCalls: []int{0, listPageSize, 2 * listPageSize, total}},
+		{name: "filtered", keep: even, wantLen: total / 2, wantCalls: []int{0, listPageSize, 2 * listPageSize, total}},
+		{name: "bounded", maxResults: 10, wantLen: 10, wantTruncated: true, wantCalls: []int{0}},
+		{name: "bounded filtered across pages", maxResults: int64(listPageSize), keep: even, wantLen: listPageSize, wantTruncated: true, wantCalls: []int{0, listPageSize, 2 * listPageSize}},
+		{name: "bound above total", maxResults: int64(total), wantLen: total, wantCalls: []int{0, listPageSize, 2 * listPageSize, total}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			items, truncated, err := listAll(fetch, tt.maxResults, tt.keep)
+			require.NoError(t, err)
+			require.Len(t, items, tt.wantLen)
+			require.Equal(t, tt.wantTruncated, truncated)
+			require.Equal(t, tt.wantCalls, calls)
+		})
+	}
+
+	// The API may return fewer items per page than requested.
+	calls = nil
+	capped := func(offset, limit int) ([]int, error) { return fetch(offset, min(limit, 50)) }
+	items, truncated, err := listAll(capped, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, items, total)
+	require.False(t, truncated)
+	require.Len(t, calls, total/50+1)
+
+	// An API ignoring the offset would otherwise be listed forever.
+	calls = nil
+	_, _, err = listAll(func(_, limit int) ([]int, error) { return fetch(0, limit) }, 0, nil)
+	require.ErrorContains(t, err, "the API returned the previous page again")
+	require.Equal(t, []int{0, 0}, calls)
+
+	_, _, err = listAll(func(int, int) ([]int, error) { return nil, errors.New("boom") }, 0, nil)
+	require.EqualError(t, err, "boom")
+}
@@ -5,15 +5,23 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
-	broadpeakio "github.com/bashou/bpkio-go-sdk"
-
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -31,6 +39,68 @@ func getenv(key, fallback string) string {
 	return value
 }
 
+// parseEndpoint checks that the configured API endpoint is an absolute
+// http(s) URL and returns it without any trailing slash.
+func parseEndpoint(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("scheme must be http or https, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("missing host")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("must not contain a query string or fragment")
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// httpClientConfig holds the provider settings applied to every API call.
+type httpClientConfig struct {
+	MaxRetries        int
+	RetryWaitMin      time.Duration
+	RetryWaitMax      time.Duration
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// newHTTPClient builds the HTTP client shared by the provider and every
+// resource and data source. Each attempt made by the retry layer goes
+// through the rate limiter and is traced separately. logCtx carries the
+// bpkio_http subsystem logger.
+func newHTTPClient(logCtx context.Context, cfg httpClientConfig) *http.Client {
+	var transport http.RoundTripper = newTraceTransport(logCtx, http.DefaultTransport)
+	if cfg.RequestsPerSecond > 0 {
+		transport = newRateLimitTransport(logCtx, transport, cfg.RequestsPerSecond, cfg.Burst)
+	}
+	return &http.Client{
+		Transport: newRetryTransport(logCtx, transport, cfg.MaxRetries, cfg.RetryWaitMin, cfg.RetryWaitMax),
+	}
+}
+
+// parseDurationAttribute reads a duration from a string attribute, falling
+// back to the given environment variable and then to the default.
+func parseDurationAttribute(value types.String, envKey string, fallback time.Duration) (time.Duration, error) {
+	raw := getenv(envKey, "")
+	if !value.IsNull() && !value.IsUnknown() {
+		raw = value.ValueString()
+	}
+	if raw == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration must not be negative, got %s", raw)
+	}
+	return d, nil
+}
+
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -46,6 +116,11 @@ type bpkioProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// client, when set, is handed to resources and data sources instead of
+	// a client built from the provider configuration. Unit tests use it to
+	// run against the in-memory fake.
+	client apiClient
 }
 
 // Metadata returns the provider type name.
@@ -60,21 +135,65 @@ func (p *bpkioProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
 				Optional:    true,
-				Description: "The Broadpeak API endpoint. Defaults to `https://api.broadpeak.io`.",
+				Description: "The Broadpeak API endpoint. Can also be set with the `BPKIO_ENDPOINT` environment variable. Defaults to `https://api.broadpeak.io`.",
 			},
 			"api_key": schema.StringAttribute{
 				Required:    true,
 				Description: "API key for Broadpeak",
 				Sensitive:   true,
 			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of times an API call is retried after a transient failure (HTTP 429, 5xx or a dropped connection). Can also be set with the `BPKIO_MAX_RETRIES` environment variable. Defaults to `4`. Set to `0` to disable retries.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				Optional:    true,
+				Description: "Minimum time to wait before retrying a failed API call, as a Go duration such as `500ms` or `1s`. The wait doubles on each attempt. Can also be set with the `BPKIO_RETRY_WAIT_MIN` environment variable. Defaults to `1s`.",
+			},
+			"retry_wait_max": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum time to wait before retrying a failed API call, including waits requested by the API through `Retry-After`. Can also be set with the `BPKIO_RETRY_WAIT_MAX` environment variable. Defaults to `30s`.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum sustained number of API calls per second, shared by every resource and data source of this provider instance. Can also be set with the `BPKIO_REQUESTS_PER_SECOND` environment variable. Defaults to `10`. Set to `0` to disable client-side rate limiting.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"burst": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API calls that can be sent at once before `requests_per_second` applies. Can also be set with the `BPKIO_BURST` environment variable. Defaults to `10`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip the API key check performed when the provider is configured, for example to run offline plans. Can also be set with the `BPKIO_SKIP_CREDENTIALS_VALIDATION` environment variable. Defaults to `false`.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Default of `deletion_protection` for the services and sources that support it and do not set it, to protect a whole workspace against `terraform destroy`. Can also be set with the `BPKIO_DELETION_PROTECTION` environment variable. Defaults to `false`.",
+			},
 		},
 	}
 }
 
 // bpkioProviderModel maps provider schema data to a Go type.
 type bpkioProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	ApiKey   types.String `tfsdk:"api_key"`
+	Endpoint                  types.String  `tfsdk:"endpoint"`
+	ApiKey                    types.String  `tfsdk:"api_key"`
+	MaxRetries                types.Int64   `tfsdk:"max_retries"`
+	RetryWaitMin              types.String  `tfsdk:"retry_wait_min"`
+	RetryWaitMax              types.String  `tfsdk:"retry_wait_max"`
+	RequestsPerSecond         types.Float64 `tfsdk:"requests_per_second"`
+	Burst                     types.Int64   `tfsdk:"burst"`
+	SkipCredentialsValidation types.Bool    `tfsdk:"skip_credentials_validation"`
+	DeletionProtection        types.Bool    `tfsdk:"deletion_protection"`
 }
 
 // Configure prepares a bpkio API client for data sources and resources.
@@ -87,6 +206,17 @@ func (p *bpkioProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
+	deletionProtection, _ := strconv.ParseBool(getenv("BPKIO_DELETION_PROTECTION", "false"))
+	if !config.DeletionProtection.IsNull() && !config.DeletionProtection.IsUnknown() {
+		deletionProtection = config.DeletionProtection.ValueBool()
+	}
+
+	if p.client != nil {
+		resp.DataSourceData = p.client
+		resp.ResourceData = &resourceClient{apiClient: p.client, deletionProtection: deletionProtection}
+		return
+	}
+
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 
@@ -126,7 +256,61 @@ func (p *bpkioProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		api_key = config.ApiKey.ValueString()
 	}
 
-	tflog.Debug(ctx, endpoint)
+	skipCredentialsValidation, _ := strconv.ParseBool(getenv("BPKIO_SKIP_CREDENTIALS_VALIDATION", "false"))
+	if !config.SkipCredentialsValidation.IsNull() && !config.SkipCredentialsValidation.IsUnknown() {
+		skipCredentialsValidation = config.SkipCredentialsValidation.ValueBool()
+	}
+
+	maxRetries := int64(4)
+	if v, err := strconv.ParseInt(getenv("BPKIO_MAX_RETRIES", ""), 10, 64); err == nil && v >= 0 {
+		maxRetries = v
+	}
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+
+	retryWaitMin, err := parseDurationAttribute(config.RetryWaitMin, "BPKIO_RETRY_WAIT_MIN", time.Second)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid bpkio Retry Wait",
+			"The retry_wait_min value must be a duration such as 500ms or 1s.\n\nError: "+err.Error(),
+		)
+	}
+
+	retryWaitMax, err := parseDurationAttribute(config.RetryWaitMax, "BPKIO_RETRY_WAIT_MAX", 30*time.Second)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_max"),
+			"Invalid bpkio Retry Wait",
+			"The retry_wait_max value must be a duration such as 10s or 1m.\n\nError: "+err.Error(),
+		)
+	}
+
+	if !resp.Diagnostics.HasError() && retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid bpkio Retry Wait",
+			fmt.Sprintf("The retry_wait_min value (%s) must not be greater than retry_wait_max (%s).", retryWaitMin, retryWaitMax),
+		)
+	}
+
+	requestsPerSecond := 10.0
+	if v, err := strconv.ParseFloat(getenv("BPKIO_REQUESTS_PER_SECOND", ""), 64); err == nil && v >= 0 {
+		requestsPerSecond = v
+	}
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+
+	burst := int64(10)
+	if v, err := strconv.ParseInt(getenv("BPKIO_BURST", ""), 10, 64); err == nil && v >= 1 {
+		burst = v
+	}
+	if !config.Burst.IsNull() && !config.Burst.IsUnknown() {
+		burst = config.Burst.ValueInt64()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -140,27 +324,82 @@ func (p *bpkioProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	endpoint, err = parseEndpoint(endpoint)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Invalid bpkio API Endpoint",
+			"The provider cannot create the bpkio API client as the configured bpkio API endpoint is not a valid URL. "+
+				"Set the endpoint value in the configuration or the BPKIO_ENDPOINT environment variable to an absolute http(s) URL, "+
+				"such as https://api.broadpeak.io.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	tflog.Debug(ctx, "Creating bpkio API client", map[string]interface{}{
+		"endpoint":            endpoint,
+		"max_retries":         maxRetries,
+		"retry_wait_min":      retryWaitMin.String(),
+		"retry_wait_max":      retryWaitMax.String(),
+		"requests_per_second": requestsPerSecond,
+		"burst":               burst,
+	})
+
+	// Every API call, from the provider or any resource and data source,
+	// goes through this client.
+	httpClient := newHTTPClient(newHTTPLogContext(ctx, api_key), httpClientConfig{
+		MaxRetries:        int(maxRetries),
+		RetryWaitMin:      retryWaitMin,
+		RetryWaitMax:      retryWaitMax,
+		RequestsPerSecond: requestsPerSecond,
+		Burst:             int(burst),
+	})
+
+	// Check the API key with a single authenticated call so that a revoked
+	// or mistyped key is reported here rather than by the first resource.
+	if skipCredentialsValidation {
+		tflog.Debug(ctx, "Skipping bpkio API key validation")
+	} else {
+		t, err := fetchCurrentTenant(ctx, httpClient, endpoint, api_key)
+		var apiErr *apiError
+		switch {
+		case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden):
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key"),
+				"Invalid bpkio API Key",
+				"The bpkio API rejected the configured API key. "+
+					"Check the api_key value in the configuration or the BPKIO_API_KEY environment variable, "+
+					"and make sure the key has not been revoked.\n\n"+
+					"bpkio Client Error: "+err.Error(),
+			)
+			return
+		case err != nil:
+			resp.Diagnostics.AddError(
+				"Unable to Validate bpkio API Key",
+				"An unexpected error occurred when checking the bpkio API key against "+endpoint+". "+
+					"Set skip_credentials_validation to true to skip this check, for example when planning offline.\n\n"+
+					"bpkio Client Error: "+err.Error(),
+			)
+			return
+		}
+
+		tflog.Info(ctx, "Authenticated to bpkio API", map[string]interface{}{
+			"tenant_id":   t.Id,
+			"tenant_name": t.Name,
+		})
+	}
+
 	// Create a new bpkio client using the configuration values
-	//TODO: Find a way to test key
-	client := broadpeakio.MakeClient(api_key)
-	//if err != nil {
-	//	resp.Diagnostics.AddError(
-	//		"Unable to Create bpkio API Client",
-	//		"An unexpected error occurred when creating the bpkio API client. "+
-	//			"If the error is not clear, please contact the provider developers.\n\n"+
-	//			"bpkio Client Error: "+err.Error(),
-	//	)
-	//	return
-	//}
+	client := newBPKIOClient(api_key, endpoint, httpClient)
 
 	// Make the bpkio client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = &client
-	resp.ResourceData = &client
+	resp.DataSourceData = client
+	resp.ResourceData = &resourceClient{apiClient: client, deletionProtection: deletionProtection}
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -170,7 +409,13 @@ func (p *bpkioProvider) DataSources(_ context.Context) []func() datasource.DataS
 		NewSourceAdServerDataSource,
 		NewSourceSlateDataSource,
 		NewSourceLiveDataSource,
+		NewSourceAssetDataSource,
+		NewSourceAssetCatalogDataSource,
+		NewSourceOriginDataSource,
 		NewServiceAdInsertionDataSource,
+		NewServiceContentReplacementDataSource,
+		NewServiceVirtualChannelDataSource,
+		NewEPGSlotsDataSource,
 		NewServicesDataSource,
 		NewTranscodingProfileDataSource,
 		NewTranscodingProfilesDataSource,
@@ -181,8 +426,17 @@ func (p *bpkioProvider) DataSources(_ context.Context) []func() datasource.DataS
 func (p *bpkioProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewServiceAdInsertionResource,
+		NewServiceContentReplacementResource,
+		NewServiceVirtualChannelResource,
+		NewVirtualChannelSlotResource,
+		NewContentReplacementSlotResource,
+		NewVirtualChannelScheduleResource,
 		NewSourceSlateResource,
 		NewSourceLiveResource,
+		NewSourceAssetResource,
+		NewSourceAssetCatalogResource,
+		NewSourceOriginResource,
 		NewSourceAdServerResource,
+		NewTranscodingProfileResource,
 	}
 }
@@ -7,14 +7,41 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"os/exec"
 	"testing"
 	"time"
 
+	"bpkio-terraform-provider/internal/bpkiomock"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
 )
 
+// testAccMock is the mock Broadpeak API serving the acceptance tests when
+// TF_ACC_MOCK=1, and nil when they run against a real tenant.
+var testAccMock *bpkiomock.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv("TF_ACC_MOCK") == "1" {
+		testAccMock = bpkiomock.NewServer()
+		os.Setenv("BPKIO_ENDPOINT", testAccMock.URL)
+		if os.Getenv("BPKIO_API_KEY") == "" {
+			os.Setenv("BPKIO_API_KEY", "mock-api-key")
+		}
+	}
+
+	code := m.Run()
+
+	if testAccMock != nil {
+		testAccMock.Close()
+	}
+	os.Exit(code)
+}
+
+// testAccProviderFactories serves the provider under test. With TF_ACC_MOCK=1
+// it talks to testAccMock, through BPKIO_ENDPOINT.
 func testAccProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
 	return map[string]func() (tfprotov6.ProviderServer, error){
 		"bpkio": func() (tfprotov6.ProviderServer, error) {
@@ -25,6 +52,29 @@ func testAccProviderFactories() map[string]func() (tfprotov6.ProviderServer, err
 	}
 }
 
+// testUnitProviderFactories serves a provider wired to client instead of the
+// Broadpeak API, so that resource lifecycles can be tested offline.
+func testUnitProviderFactories(client apiClient) map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"bpkio": func() (tfprotov6.ProviderServer, error) {
+			p := &bpkioProvider{version: "test", client: client}
+			factory := providerserver.NewProtocol6WithError(p)
+			return factory()
+		},
+	}
+}
+
+// testUnitPreCheck skips lifecycle unit tests when no Terraform CLI is
+// available locally; they need no network but still run terraform.
+func testUnitPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform not found in PATH; set TF_ACC_TERRAFORM_PATH to run lifecycle tests")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("BPKIO_API_KEY"); v == "" {
 		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
@@ -52,3 +102,34 @@ provider "bpkio" {}
 		},
 	})
 }
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "default", input: "https://api.broadpeak.io", want: "https://api.broadpeak.io"},
+		{name: "trailing slash", input: "https://staging.example.com/", want: "https://staging.example.com"},
+		{name: "local http with port", input: "http://127.0.0.1:8080", want: "http://127.0.0.1:8080"},
+		{name: "base path", input: "https://gw.example.com/bpkio/", want: "https://gw.example.com/bpkio"},
+		{name: "missing scheme", input: "api.broadpeak.io", wantErr: true},
+		{name: "unsupported scheme", input: "ftp://api.broadpeak.io", wantErr: true},
+		{name: "missing host", input: "https://", wantErr: true},
+		{name: "query string", input: "https://api.broadpeak.io?x=1", wantErr: true},
+		{name: "unparsable", input: "https://[::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEndpoint(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
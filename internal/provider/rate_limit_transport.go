@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ http.RoundTripper = &rateLimitTransport{}
+
+// tokenBucket is a token-bucket rate limiter safe for concurrent use. Tokens
+// are added at rate per second up to burst; a caller that finds the bucket
+// empty reserves a future token and waits for it.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// reserve takes one token and returns how long the caller must wait before
+// using it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back a token reserved by a caller that stopped waiting.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+// rateLimitTransport delays API calls so that all resources and data sources
+// of a provider instance stay under the configured request rate together.
+type rateLimitTransport struct {
+	next   http.RoundTripper
+	logCtx context.Context
+	bucket *tokenBucket
+}
+
+func newRateLimitTransport(logCtx context.Context, next http.RoundTripper, requestsPerSecond float64, burst int) *rateLimitTransport {
+	return &rateLimitTransport{
+		next:   next,
+		logCtx: logCtx,
+		bucket: newTokenBucket(requestsPerSecond, burst),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if wait := t.bucket.reserve(); wait > 0 {
+		tflog.SubsystemDebug(t.logCtx, httpLogSubsystem, "Delaying bpkio API request to respect the client-side rate limit", map[string]interface{}{
+			"method": req.Method,
+			"path":   req.URL.Path,
+			"wait":   wait.String(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			t.bucket.cancel()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(2, 3)
+	b.now = func() time.Time { return now }
+
+	// The burst is available immediately.
+	for i := 0; i < 3; i++ {
+		require.Equal(t, time.Duration(0), b.reserve())
+	}
+
+	// The next calls queue up at 2 requests per second.
+	require.Equal(t, 500*time.Millisecond, b.reserve())
+	require.Equal(t, time.Second, b.reserve())
+
+	// A waiter that gives up returns its token.
+	b.cancel()
+	require.Equal(t, time.Second, b.reserve())
+
+	// Tokens refill over time, but never above the burst.
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		require.Equal(t, time.Duration(0), b.reserve())
+	}
+	require.Equal(t, 500*time.Millisecond, b.reserve())
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(context.Background(), http.DefaultTransport, 50, 2)}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := client.Get(srv.URL)
+			if err == nil {
+				res.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 2 calls go out at once, the 4 others are spaced by 20ms.
+	require.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+}
+
+func TestRateLimitTransport_ContextCancelled(t *testing.T) {
+	tr := newRateLimitTransport(context.Background(), http.DefaultTransport, 0.001, 1)
+	tr.bucket.reserve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1", nil)
+	require.NoError(t, err)
+
+	_, err = tr.RoundTrip(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
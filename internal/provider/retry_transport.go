@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ http.RoundTripper = &retryTransport{}
+
+// retryTransport retries Broadpeak API calls that failed with a transient
+// error (429, 5xx or a dropped connection) using exponential backoff.
+//
+// Requests with a non-idempotent method (POST, PATCH) are only retried when
+// the API could not have processed them: the server explicitly asked the
+// client to come back later (429, or 503 with Retry-After), or the connection
+// could not be established at all.
+type retryTransport struct {
+	next       http.RoundTripper
+	logCtx     context.Context
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+func newRetryTransport(logCtx context.Context, next http.RoundTripper, maxRetries int, waitMin, waitMax time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		logCtx:     logCtx,
+		maxRetries: maxRetries,
+		waitMin:    waitMin,
+		waitMax:    waitMax,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// Buffer the body once so it can be replayed on every attempt.
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(ctx)
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+			attemptReq.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(body)), nil
+			}
+		}
+
+		res, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || ctx.Err() != nil || !t.shouldRetry(req.Method, res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+
+		logFields := map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			logFields["error"] = err.Error()
+		} else {
+			logFields["status"] = res.StatusCode
+			// Drain the body so the underlying connection can be reused.
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		tflog.SubsystemDebug(t.logCtx, httpLogSubsystem, "Retrying bpkio API request", logFields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether a request may be sent again after the given
+// outcome.
+func (t *retryTransport) shouldRetry(method string, res *http.Response, err error) bool {
+	if err != nil {
+		if isDialError(err) {
+			return true
+		}
+		return isIdempotent(method) && isTransientNetworkError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return isIdempotent(method) || res.Header.Get("Retry-After") != ""
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header sent by the API takes precedence over the exponential schedule; both
+// are capped at waitMax.
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return min(max(wait, t.waitMin), t.waitMax)
+		}
+	}
+
+	// A shift that overflows falls back to waitMax; a zero waitMin keeps
+	// retrying without waiting.
+	wait := t.waitMin << attempt
+	if (t.waitMin > 0 && wait <= 0) || wait > t.waitMax {
+		wait = t.waitMax
+	}
+
+	// Add up to 25% of jitter so that parallel operations throttled at the
+	// same time do not retry in lockstep.
+	if jitter := int64(wait / 4); jitter > 0 {
+		wait += time.Duration(rand.Int63n(jitter))
+	}
+	return min(wait, t.waitMax)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isDialError reports whether the request failed before reaching the API,
+// in which case it is always safe to send it again.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer answers with the given status codes in order, then 200.
+func flakyServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		body, _ := io.ReadAll(r.Body)
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestRetryTransport(t *testing.T) {
+	client := &http.Client{
+		Transport: newRetryTransport(context.Background(), http.DefaultTransport, 3, time.Millisecond, 5*time.Millisecond),
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		statuses   []int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{name: "get retried on 502", method: http.MethodGet, statuses: []int{502, 503}, wantStatus: 200, wantCalls: 3},
+		{name: "put retried on 500", method: http.MethodPut, statuses: []int{500}, wantStatus: 200, wantCalls: 2},
+		{name: "post retried on 429", method: http.MethodPost, statuses: []int{429}, wantStatus: 200, wantCalls: 2},
+		{name: "post not retried on 502", method: http.MethodPost, statuses: []int{502}, wantStatus: 502, wantCalls: 1},
+		{name: "client errors not retried", method: http.MethodGet, statuses: []int{404}, wantStatus: 404, wantCalls: 1},
+		{name: "gives up after max retries", method: http.MethodGet, statuses: []int{502, 502, 502, 502, 502}, wantStatus: 502, wantCalls: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := flakyServer(t, tt.statuses...)
+
+			req, err := http.NewRequest(tt.method, srv.URL, strings.NewReader(`{"name":"x"}`))
+			require.NoError(t, err)
+
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			require.Equal(t, tt.wantStatus, res.StatusCode)
+			require.Equal(t, tt.wantCalls, atomic.LoadInt32(calls))
+			if res.StatusCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				require.Equal(t, `{"name":"x"}`, string(body), "request body must be replayed on retries")
+			}
+		})
+	}
+}
+
+func TestRetryTransport_RetryAfter(t *testing.T) {
+	tr := newRetryTransport(context.Background(), http.DefaultTransport, 1, time.Millisecond, 2*time.Second)
+
+	res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	res.Header.Set("Retry-After", "1")
+	require.Equal(t, time.Second, tr.backoff(0, res))
+
+	// Waits requested by the API are capped at retry_wait_max.
+	res.Header.Set("Retry-After", "120")
+	require.Equal(t, 2*time.Second, tr.backoff(0, res))
+
+	// 503 is only retried for POST when the API sent Retry-After.
+	require.True(t, tr.shouldRetry(http.MethodPost, res, nil))
+	res.Header.Del("Retry-After")
+	res.StatusCode = http.StatusServiceUnavailable
+	require.False(t, tr.shouldRetry(http.MethodPost, res, nil))
+	require.True(t, tr.shouldRetry(http.MethodGet, res, nil))
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	tr := newRetryTransport(context.Background(), http.DefaultTransport, 1, time.Second, 30*time.Second)
+	require.GreaterOrEqual(t, tr.backoff(2, nil), 4*time.Second)
+	require.Less(t, tr.backoff(2, nil), 5*time.Second)
+	require.Equal(t, 30*time.Second, tr.backoff(10, nil))
+	require.Equal(t, 30*time.Second, tr.backoff(80, nil), "an overflowing shift waits retry_wait_max")
+
+	// A zero retry_wait_min retries immediately instead of waiting
+	// retry_wait_max.
+	tr = newRetryTransport(context.Background(), http.DefaultTransport, 1, 0, 30*time.Second)
+	require.Equal(t, time.Duration(0), tr.backoff(0, nil))
+	require.Equal(t, time.Duration(0), tr.backoff(5, nil))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("3")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.Equal(t, time.Duration(0), d)
+
+	_, ok = parseRetryAfter("soon")
+	require.False(t, ok)
+
+	_, ok = parseRetryAfter("")
+	require.False(t, ok)
+}
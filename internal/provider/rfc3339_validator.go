@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = rfc3339Validator{}
+
+// rfc3339Validator checks that a string is an RFC3339 timestamp such as
+// `2025-06-01T20:00:00Z`, so that typos are caught at plan time rather than
+// by the API.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be an RFC3339 timestamp, such as 2025-06-01T20:00:00Z"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			"The value "+req.ConfigValue.String()+" is not valid: "+v.Description(ctx)+".",
+		)
+	}
+}
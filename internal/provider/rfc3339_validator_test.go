@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRFC3339Validator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{name: "utc", value: types.StringValue("2025-06-01T20:00:00Z")},
+		{name: "offset", value: types.StringValue("2025-06-01T22:00:00+02:00")},
+		{name: "fractional seconds", value: types.StringValue("2025-06-01T20:00:00.500Z")},
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "date only", value: types.StringValue("2025-06-01"), wantErr: true},
+		{name: "missing zone", value: types.StringValue("2025-06-01T20:00:00"), wantErr: true},
+		{name: "space separator", value: types.StringValue("2025-06-01 20:00:00Z"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("start_time"), ConfigValue: tt.value}
+			var resp validator.StringResponse
+			rfc3339Validator{}.ValidateString(context.Background(), req, &resp)
+			require.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
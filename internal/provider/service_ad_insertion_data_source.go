@@ -7,8 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	broadpeakio "github.com/bashou/bpkio-go-sdk"
-
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -23,7 +21,7 @@ var (
 
 // serviceAdInsertionDataSource is the data source implementation.
 type serviceAdInsertionDataSource struct {
-	client *broadpeakio.BroadpeakClient
+	client apiClient
 }
 
 // NewServiceAdInsertionDataSource is a helper function to simplify the provider implementation.
@@ -39,11 +37,11 @@ func (d *serviceAdInsertionDataSource) Configure(_ context.Context, req datasour
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -231,6 +229,54 @@ func (d *serviceAdInsertionDataSource) Schema(_ context.Context, _ datasource.Sc
 				Computed:    true,
 				Description: "Configuration of live mid-roll",
 			},
+			"vod_ad_insertion": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"ad_server": schema.SingleNestedAttribute{
+						Attributes: map[string]schema.Attribute{
+							"id": schema.Int64Attribute{
+								Computed:    true,
+								Description: "The ID of the ad server.",
+							},
+							"name": schema.StringAttribute{
+								Computed:    true,
+								Description: "The name of the ad server.",
+							},
+							"type": schema.StringAttribute{
+								Computed:    true,
+								Description: "The type of the ad server.",
+							},
+							"url": schema.StringAttribute{
+								Computed:    true,
+								Description: "The URL of the ad server.",
+							},
+							"query_parameters": schema.ListNestedAttribute{
+								Computed:    true,
+								Description: "The query parameters passed to the ad server requests.",
+								NestedObject: schema.NestedAttributeObject{
+									Attributes: map[string]schema.Attribute{
+										"type": schema.StringAttribute{
+											Computed:    true,
+											Description: "The type of the query parameter (values: `custom`, `forward`, `from-query-parameter`, `from-variable`, `from-header`).",
+										},
+										"name": schema.StringAttribute{
+											Computed:    true,
+											Description: "The name of the query parameter.",
+										},
+										"value": schema.StringAttribute{
+											Computed:    true,
+											Description: "The value of the query parameter.",
+										},
+									},
+								},
+							},
+						},
+						Computed:    true,
+						Description: "Configuration of ad server",
+					},
+				},
+				Computed:    true,
+				Description: "Configuration of VOD ad insertion",
+			},
 			"advanced_options": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"authorization_header": schema.SingleNestedAttribute{
@@ -335,7 +381,8 @@ func (d *serviceAdInsertionDataSource) Schema(_ context.Context, _ datasource.Sc
 						Computed: true,
 					},
 					"content": schema.StringAttribute{
-						Computed: true,
+						CustomType: jsonStringType{},
+						Computed:   true,
 					},
 				},
 				Optional:    true,
@@ -361,7 +408,7 @@ func (d *serviceAdInsertionDataSource) Read(ctx context.Context, req datasource.
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
 	// Get the service from the API
-	service, err := d.client.GetAdInsertion(uint(serviceid))
+	service, err := d.client.GetAdInsertion(ctx, uint(serviceid))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Single Service",
@@ -415,7 +462,7 @@ func (d *serviceAdInsertionDataSource) Read(ctx context.Context, req datasource.
 			ID:         types.Int64Value(int64(service.TranscodingProfile.Id)),
 			Name:       types.StringValue(service.TranscodingProfile.Name),
 			InternalId: types.StringValue(service.TranscodingProfile.InternalId),
-			Content:    types.StringValue(service.TranscodingProfile.Content),
+			Content:    newJSONStringValue(service.TranscodingProfile.Content),
 		},
 		AdvancedOptions: &advancedOptionsModel{
 			AuthorizationHeader: &authorizationHeaderModel{
@@ -480,6 +527,8 @@ func (d *serviceAdInsertionDataSource) Read(ctx context.Context, req datasource.
 		}
 	}
 
+	serviceState.VodAdInsertion = flattenVodAdInsertionOutput(service.VodAdInsertion)
+
 	// Set state
 	diags = resp.State.Set(ctx, &serviceState)
 	resp.Diagnostics.Append(diags...)
@@ -488,7 +537,7 @@ func (d *serviceAdInsertionDataSource) Read(ctx context.Context, req datasource.
 	}
 }
 
-func flattenAdInsertionOutput(s broadpeakio.AdInsertionOutput, ctx context.Context) (*serviceAdInsertionDataSourceModel, error) {
+func flattenAdInsertionOutput(s apiAdInsertion, ctx context.Context) (*serviceAdInsertionDataSourceModel, error) {
 	// Tags
 	tagsList, diags := types.ListValueFrom(ctx, types.StringType, s.Tags)
 	if diags.HasError() {
@@ -539,7 +588,7 @@ func flattenAdInsertionOutput(s broadpeakio.AdInsertionOutput, ctx context.Conte
 		ID:         types.Int64Value(int64(s.TranscodingProfile.Id)),
 		Name:       types.StringValue(s.TranscodingProfile.Name),
 		InternalId: types.StringValue(s.TranscodingProfile.InternalId),
-		Content:    types.StringValue(s.TranscodingProfile.Content),
+		Content:    newJSONStringValue(s.TranscodingProfile.Content),
 	}
 
 	// LiveAdPreroll
@@ -613,9 +662,35 @@ func flattenAdInsertionOutput(s broadpeakio.AdInsertionOutput, ctx context.Conte
 		AdvancedOptions:      advancedOptions,
 		LiveAdPreRoll:        liveAdPreroll,
 		LiveAdReplacement:    liveAdReplacement,
+		VodAdInsertion:       flattenVodAdInsertionOutput(s.VodAdInsertion),
 	}, nil
 }
 
+// flattenVodAdInsertionOutput returns the model of the VOD ad insertion of a
+// service, nil when the service has none.
+func flattenVodAdInsertionOutput(vod apiVodAdInsertion) *vodAdInsertionModel {
+	if vod.AdServer.Id == 0 {
+		return nil
+	}
+	var params []queryParametersModel
+	for _, p := range vod.AdServer.QueryParameters {
+		params = append(params, queryParametersModel{
+			Type:  types.StringValue(p.Type),
+			Name:  types.StringValue(p.Name),
+			Value: types.StringValue(p.Value),
+		})
+	}
+	return &vodAdInsertionModel{
+		AdServer: adServerModel{
+			ID:              types.Int64Value(int64(vod.AdServer.Id)),
+			Name:            types.StringValue(vod.AdServer.Name),
+			Type:            types.StringValue(vod.AdServer.Type),
+			URL:             types.StringValue(vod.AdServer.Url),
+			QueryParameters: params,
+		},
+	}
+}
+
 // serviceModel maps service schema data.
 type serviceAdInsertionDataSourceModel struct {
 	ID                   types.Int64                        `tfsdk:"id"`
@@ -629,6 +704,7 @@ type serviceAdInsertionDataSourceModel struct {
 	AdvancedOptions      *advancedOptionsModel              `tfsdk:"advanced_options"`
 	LiveAdPreRoll        *liveAdPrerollModel                `tfsdk:"live_ad_preroll"`
 	LiveAdReplacement    *liveAdReplacementModel            `tfsdk:"live_ad_replacement"`
+	VodAdInsertion       *vodAdInsertionModel               `tfsdk:"vod_ad_insertion"`
 	EnableAdTranscoding  types.Bool                         `tfsdk:"enable_ad_transcoding"`
 	ServerSideAdTracking *serverSideAdTrackingModel         `tfsdk:"server_side_ad_tracking"`
 	Source               *sourceModel                       `tfsdk:"source"`
@@ -681,6 +757,10 @@ type liveAdReplacementModel struct {
 	SpotAware spotAwareModel `tfsdk:"spot_aware"`
 }
 
+type vodAdInsertionModel struct {
+	AdServer adServerModel `tfsdk:"ad_server"`
+}
+
 type spotAwareModel struct {
 	Mode types.String `tfsdk:"mode"`
 }
@@ -86,7 +86,7 @@ func TestFlattenAdInsertionOutput_basic(t *testing.T) {
 		},
 	}
 
-	out, err := flattenAdInsertionOutput(in, ctx)
+	out, err := flattenAdInsertionOutput(apiAdInsertion{AdInsertionOutput: in}, ctx)
 	require.NoError(t, err)
 	require.Equal(t, types.Int64Value(1), out.ID)
 	require.Equal(t, types.StringValue("AI Service"), out.Name)
@@ -104,4 +104,34 @@ func TestFlattenAdInsertionOutput_basic(t *testing.T) {
 	require.Equal(t, types.StringValue("gapfiller"), out.LiveAdReplacement.GapFiller.Name)
 	require.Equal(t, types.StringValue("spot_to_live"), out.LiveAdReplacement.SpotAware.Mode)
 	require.Len(t, out.Tags.Elements(), 2)
+	require.Nil(t, out.VodAdInsertion)
+}
+
+func TestFlattenAdInsertionOutput_vod(t *testing.T) {
+	in := apiAdInsertion{
+		AdInsertionOutput: broadpeakio.AdInsertionOutput{
+			Id:     2,
+			Name:   "VOD Service",
+			Source: broadpeakio.Source{Id: 10, Name: "catalog", Type: "asset-catalog"},
+		},
+		VodAdInsertion: apiVodAdInsertion{
+			AdServer: broadpeakio.AdServer{
+				Id:   30,
+				Name: "vod-ads",
+				Type: "ad-server",
+				Url:  "http://vod-ads",
+				QueryParameters: []broadpeakio.QueryParam{
+					{Type: "custom", Name: "ctx", Value: "vod"},
+				},
+			},
+		},
+	}
+
+	out, err := flattenAdInsertionOutput(in, context.Background())
+	require.NoError(t, err)
+	require.Nil(t, out.LiveAdReplacement)
+	require.NotNil(t, out.VodAdInsertion)
+	require.Equal(t, types.Int64Value(30), out.VodAdInsertion.AdServer.ID)
+	require.Equal(t, types.StringValue("vod-ads"), out.VodAdInsertion.AdServer.Name)
+	require.Len(t, out.VodAdInsertion.AdServer.QueryParameters, 1)
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -30,9 +31,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &serviceAdInsertionResource{}
-	_ resource.ResourceWithConfigure   = &serviceAdInsertionResource{}
-	_ resource.ResourceWithImportState = &serviceAdInsertionResource{}
+	_ resource.Resource                   = &serviceAdInsertionResource{}
+	_ resource.ResourceWithConfigure      = &serviceAdInsertionResource{}
+	_ resource.ResourceWithImportState    = &serviceAdInsertionResource{}
+	_ resource.ResourceWithValidateConfig = &serviceAdInsertionResource{}
+	_ resource.ResourceWithModifyPlan     = &serviceAdInsertionResource{}
 )
 
 // NewServiceAdInsertionResource is a helper function to simplify the provider implementation.
@@ -42,7 +45,8 @@ func NewServiceAdInsertionResource() resource.Resource {
 
 // serviceAdInsertionResource is the resource implementation.
 type serviceAdInsertionResource struct {
-	client *broadpeakio.BroadpeakClient
+	client             apiClient
+	deletionProtection bool
 }
 
 // Configure adds the provider configured client to the resource.
@@ -52,17 +56,18 @@ func (r *serviceAdInsertionResource) Configure(_ context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
 	r.client = client
+	r.deletionProtection = providerDeletionProtection(req.ProviderData)
 }
 
 // Metadata returns the resource type name.
@@ -119,13 +124,15 @@ func (r *serviceAdInsertionResource) Schema(_ context.Context, _ resource.Schema
 				},
 			},
 			"state": schema.StringAttribute{
+				Optional:    true,
 				Computed:    true,
-				Description: "State of the ad insertion service. This indicates the current state of the service. Possible values are 'enabled', 'paused', or 'bypassed'.",
+				Description: "State of the ad insertion service. Possible values are 'enabled', 'paused', or 'bypassed'. A bypassed service plays the source without inserting ads. Changes made outside of Terraform are reported as drift.",
 				Default:     stringdefault.StaticString("enabled"),
 				Validators: []validator.String{
 					stringvalidator.OneOf("enabled", "paused", "bypassed"),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("ad insertion service"),
 			"tags": schema.ListAttribute{
 				Optional:    true,
 				Computed:    true,
@@ -266,6 +273,43 @@ func (r *serviceAdInsertionResource) Schema(_ context.Context, _ resource.Schema
 				},
 				Optional: true,
 			},
+			"vod_ad_insertion": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"ad_server": schema.SingleNestedAttribute{
+						Attributes: map[string]schema.Attribute{
+							"id": schema.Int64Attribute{
+								Required:    true,
+								Description: "ID of the ad server. This is a unique identifier for the ad server.",
+							},
+							"name": schema.StringAttribute{
+								Computed:    true,
+								Description: "Name of the ad server. This is a human-readable name for the ad server.",
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.UseStateForUnknown(),
+								},
+							},
+							"type": schema.StringAttribute{
+								Computed:    true,
+								Description: "Type of the ad server. This indicates the type of ad server being used.",
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.UseStateForUnknown(),
+								},
+							},
+							"url": schema.StringAttribute{
+								Computed:    true,
+								Description: "URL of the ad server. This is the endpoint where the ad server can be accessed.",
+								PlanModifiers: []planmodifier.String{
+									stringplanmodifier.UseStateForUnknown(),
+								},
+							},
+						},
+						Required:    true,
+						Description: "Ad server configuration. This is the ad server used for VOD ad insertion.",
+					},
+				},
+				Optional:    true,
+				Description: "VOD ad insertion configuration, for services whose source is an asset or an asset catalog. Cannot be combined with a live source, `live_ad_replacement` or `live_ad_preroll`. A live source is reported by `terraform plan` when its ID is known; a source created in the same apply is only checked by the API.",
+			},
 			"advanced_options": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"authorization_header": schema.SingleNestedAttribute{
@@ -388,8 +432,9 @@ func (r *serviceAdInsertionResource) Schema(_ context.Context, _ resource.Schema
 						},
 					},
 					"content": schema.StringAttribute{
-						Optional: true,
-						Computed: true,
+						CustomType: jsonStringType{},
+						Optional:   true,
+						Computed:   true,
 						PlanModifiers: []planmodifier.String{
 							stringplanmodifier.UseStateForUnknown(),
 						},
@@ -431,9 +476,12 @@ func (r *serviceAdInsertionResource) Create(
 		}
 	}
 
-	input := broadpeakio.CreateAdInsertionInput{
-		Name: plan.Name.ValueString(),
-		Tags: tags,
+	input := apiAdInsertionInput{
+		CreateAdInsertionInput: broadpeakio.CreateAdInsertionInput{
+			Name: plan.Name.ValueString(),
+			Tags: tags,
+		},
+		VodAdInsertion: plan.VodAdInsertion.expand(),
 	}
 
 	// Optional fields.
@@ -505,18 +553,21 @@ func (r *serviceAdInsertionResource) Create(
 	//--------------------------------------------------------------------.
 	// 3. Call Broadpeak API.
 	//--------------------------------------------------------------------.
-	service, err := r.client.CreateAdInsertion(input)
+	service, err := r.client.CreateAdInsertion(ctx, input)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Ad-Insertion", err.Error())
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error creating Ad-Insertion", "Could not create Ad-Insertion", err)
 		return
 	}
+	service.State = r.applyState(ctx, &resp.Diagnostics, service.Id, service.State, plan.State)
 
 	//--------------------------------------------------------------------.
 	// 4. Build Terraform state.
 	//--------------------------------------------------------------------.
+	// The service exists from here on: it is saved even when applyState
+	// failed, so that Terraform taints it instead of losing track of it.
 	tagsList, diags := types.ListValueFrom(ctx, types.StringType, service.Tags)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	if diags.HasError() {
 		return
 	}
 
@@ -530,6 +581,7 @@ func (r *serviceAdInsertionResource) Create(
 		State:               types.StringValue(service.State),
 		Tags:                tagsList,
 		EnableAdTranscoding: types.BoolValue(service.EnableAdTranscoding),
+		DeletionProtection:  plan.DeletionProtection,
 	}
 
 	// Server-side ad-tracking.
@@ -546,7 +598,7 @@ func (r *serviceAdInsertionResource) Create(
 			ID:         types.Int64Value(int64(service.TranscodingProfile.Id)),
 			Name:       types.StringValue(service.TranscodingProfile.Name),
 			InternalId: types.StringValue(service.TranscodingProfile.InternalId),
-			Content:    types.StringValue(service.TranscodingProfile.Content),
+			Content:    newJSONStringValue(service.TranscodingProfile.Content),
 		}
 	}
 
@@ -603,6 +655,8 @@ func (r *serviceAdInsertionResource) Create(
 		}
 	}
 
+	state.VodAdInsertion = flattenVodAdInsertion(service.VodAdInsertion)
+
 	// Advanced options.
 	if service.AdvancedOptions.AuthorizationHeader.Name != "" || service.AdvancedOptions.AuthorizationHeader.Value != "" {
 		state.AdvancedOptions = &advancedOptionsModel{
@@ -627,7 +681,12 @@ func (r *serviceAdInsertionResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	service, err := r.client.GetAdInsertion(uint(state.ID.ValueInt64()))
+	service, err := r.client.GetAdInsertion(ctx, uint(state.ID.ValueInt64()))
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Ad insertion service not found, removing from state", map[string]interface{}{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Single Service",
@@ -650,11 +709,13 @@ func (r *serviceAdInsertionResource) Read(ctx context.Context, req resource.Read
 		State:                toStringOrEmpty(service.State),
 		Tags:                 tagsList,
 		EnableAdTranscoding:  types.BoolValue(service.EnableAdTranscoding),
+		DeletionProtection:   refreshedDeletionProtection(state.DeletionProtection, r.deletionProtection),
 		ServerSideAdTracking: nil,
 		Source:               nil,
 		TranscodingProfile:   nil,
 		LiveAdReplacement:    nil,
 		LiveAdPreRoll:        nil,
+		VodAdInsertion:       flattenVodAdInsertion(service.VodAdInsertion),
 		AdvancedOptions:      nil,
 	}
 
@@ -694,14 +755,14 @@ func (r *serviceAdInsertionResource) Read(ctx context.Context, req resource.Read
 			ID:         types.Int64Value(int64(service.TranscodingProfile.Id)),
 			Name:       toStringOrEmpty(service.TranscodingProfile.Name),
 			InternalId: toStringOrEmpty(service.TranscodingProfile.InternalId),
-			Content:    toStringOrEmpty(service.TranscodingProfile.Content),
+			Content:    newJSONStringValue(service.TranscodingProfile.Content),
 		}
 	} else {
 		state.TranscodingProfile = &transcodingProfileDataSourceModel{
 			ID:         types.Int64Null(),
 			Name:       types.StringValue(""),
 			InternalId: types.StringValue(""),
-			Content:    types.StringValue(""),
+			Content:    newJSONStringValue(""),
 		}
 	}
 
@@ -772,6 +833,166 @@ func (r *serviceAdInsertionResource) Read(ctx context.Context, req resource.Read
 	resp.Diagnostics.Append(diags...)
 }
 
+// ValidateConfig rejects VOD ad insertion combined with live ad insertion.
+func (r *serviceAdInsertionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config serviceAdInsertionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.VodAdInsertion == nil {
+		return
+	}
+
+	conflicts := []struct {
+		name string
+		set  bool
+	}{
+		{"live_ad_replacement", config.LiveAdReplacement != nil},
+		{"live_ad_preroll", config.LiveAdPreRoll != nil},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("vod_ad_insertion"),
+				"Conflicting Ad Insertion Configuration",
+				fmt.Sprintf("vod_ad_insertion cannot be combined with %s: a service inserts ads either in a live or in a VOD source.", conflict.name),
+			)
+		}
+	}
+}
+
+// adInsertionReference is an object of the tenant referenced by the plan,
+// with the type it must have and the call that fetches the type of the
+// object.
+type adInsertionReference struct {
+	path       path.Path
+	id         types.Int64
+	kind       string
+	objectType string
+	get        func(id uint) (string, error)
+}
+
+// references returns the objects referenced by the plan whose type is
+// constrained: the source of live ad insertion must be live, ad servers must
+// be ad servers and the gap filler a slate.
+func (r *serviceAdInsertionResource) references(plan serviceAdInsertionResourceModel) []adInsertionReference {
+	getLive := func(id uint) (string, error) { s, err := r.client.GetLive(id); return s.Type, err }
+	getSlate := func(id uint) (string, error) { s, err := r.client.GetSlate(id); return s.Type, err }
+	getAdServer := func(id uint) (string, error) { s, err := r.client.GetAdServer(id); return s.Type, err }
+
+	var refs []adInsertionReference
+	if plan.Source != nil && (plan.LiveAdReplacement != nil || plan.LiveAdPreRoll != nil) {
+		refs = append(refs, adInsertionReference{path.Root("source").AtName("id"), plan.Source.ID, "a live source", "live", getLive})
+	}
+	if plan.LiveAdReplacement != nil {
+		refs = append(refs,
+			adInsertionReference{path.Root("live_ad_replacement").AtName("ad_server").AtName("id"), plan.LiveAdReplacement.AdServer.ID, "an ad server", "ad-server", getAdServer},
+			adInsertionReference{path.Root("live_ad_replacement").AtName("gap_filler").AtName("id"), plan.LiveAdReplacement.GapFiller.ID, "a slate", "slate", getSlate},
+		)
+	}
+	if plan.LiveAdPreRoll != nil {
+		refs = append(refs, adInsertionReference{path.Root("live_ad_preroll").AtName("ad_server").AtName("id"), plan.LiveAdPreRoll.AdServer.ID, "an ad server", "ad-server", getAdServer})
+	}
+	if plan.VodAdInsertion != nil {
+		refs = append(refs, adInsertionReference{path.Root("vod_ad_insertion").AtName("ad_server").AtName("id"), plan.VodAdInsertion.AdServer.ID, "an ad server", "ad-server", getAdServer})
+	}
+	return refs
+}
+
+// checkReferences fetches the objects referenced by plan whose IDs are known
+// and differ from prior, the state of an existing service or nil, and
+// reports the references to objects of the wrong type, which the API would
+// only reject during apply. Objects that cannot be fetched for another
+// reason are left for the API to check.
+func (r *serviceAdInsertionResource) checkReferences(ctx context.Context, plan serviceAdInsertionResourceModel, prior *serviceAdInsertionResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// References of the state were checked when they were planned.
+	current := make(map[string]types.Int64)
+	if prior != nil {
+		for _, ref := range r.references(*prior) {
+			current[ref.path.String()] = ref.id
+		}
+	}
+
+	for _, ref := range r.references(plan) {
+		if ref.id.IsNull() || ref.id.IsUnknown() || ref.id.Equal(current[ref.path.String()]) {
+			continue
+		}
+		id := ref.id.ValueInt64()
+		objectType, err := ref.get(uint(id))
+		switch {
+		case err == nil && objectType == ref.objectType:
+		case err == nil, isNotFound(err):
+			diags.AddAttributeError(
+				ref.path,
+				"Invalid Ad Insertion Reference",
+				fmt.Sprintf("ID %d does not reference %s of the tenant. Check that the ID points at the intended object.", id, ref.kind),
+			)
+		default:
+			tflog.Debug(ctx, "Could not check an ad insertion reference", map[string]interface{}{"id": id, "error": err.Error()})
+		}
+	}
+
+	if plan.VodAdInsertion == nil || plan.Source == nil || plan.Source.ID.IsUnknown() || plan.Source.ID.IsNull() {
+		return diags
+	}
+	if prior != nil && prior.VodAdInsertion != nil && prior.Source != nil && prior.Source.ID.Equal(plan.Source.ID) {
+		return diags
+	}
+	sourceID := plan.Source.ID.ValueInt64()
+	if source, err := r.client.GetLive(uint(sourceID)); err == nil && source.Type == "live" {
+		diags.AddAttributeError(
+			path.Root("source").AtName("id"),
+			"VOD Ad Insertion With Live Source",
+			fmt.Sprintf("Source ID %d is a live source, which does not support vod_ad_insertion. Use an asset or asset catalog source, or live_ad_replacement.", sourceID),
+		)
+	} else if err != nil && !isNotFound(err) {
+		tflog.Debug(ctx, "Could not check the type of the source", map[string]interface{}{"id": sourceID, "error": err.Error()})
+	}
+	return diags
+}
+
+// ModifyPlan plans the provider default of deletion_protection and checks the
+// references of the plan against the tenant, so that IDs of the wrong object
+// are reported by terraform plan.
+func (r *serviceAdInsertionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, req, resp, r.deletionProtection)
+	if req.Plan.Raw.IsNull() || r.client == nil || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan serviceAdInsertionResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	var prior *serviceAdInsertionResourceModel
+	if !req.State.Raw.IsNull() {
+		prior = &serviceAdInsertionResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.checkReferences(ctx, plan, prior)...)
+}
+
+// applyState switches the service to the planned state through the API's
+// state-change calls when current differs from it, and returns the state the
+// service is left in. A failure is reported on diags and leaves current.
+func (r *serviceAdInsertionResource) applyState(ctx context.Context, diags *diag.Diagnostics, id uint, current string, planned types.String) string {
+	if planned.IsNull() || planned.IsUnknown() || planned.ValueString() == current {
+		return current
+	}
+
+	tflog.Debug(ctx, "Changing ad insertion service state", map[string]interface{}{"id": id, "from": current, "to": planned.ValueString()})
+	if err := r.client.SetAdInsertionState(ctx, id, planned.ValueString()); err != nil {
+		diags.AddAttributeError(
+			path.Root("state"),
+			"Error Changing Ad-Insertion State",
+			fmt.Sprintf("Could not switch ad insertion service ID %d from %s to %s: %s", id, current, planned.ValueString(), err),
+		)
+		return current
+	}
+	return planned.ValueString()
+}
+
 // Helper.
 func toStringOrEmpty(s string) types.String {
 	if s == "" {
@@ -801,9 +1022,12 @@ func (r *serviceAdInsertionResource) Update(ctx context.Context, req resource.Up
 		}
 	}
 
-	var serviceData = broadpeakio.UpdateAdInsertionInput{
-		Name: plan.Name.ValueString(),
-		Tags: tags,
+	var serviceData = apiAdInsertionInput{
+		CreateAdInsertionInput: broadpeakio.CreateAdInsertionInput{
+			Name: plan.Name.ValueString(),
+			Tags: tags,
+		},
+		VodAdInsertion: plan.VodAdInsertion.expand(),
 	}
 
 	// Add TranscodingProfile if provided.
@@ -889,17 +1113,18 @@ func (r *serviceAdInsertionResource) Update(ctx context.Context, req resource.Up
 	tflog.Debug(ctx, "Update - Update Doc sent to BPKIO", map[string]interface{}{"id": adinsertionID, "updates": serviceData})
 
 	// Update existing adserver.
-	_, err := r.client.UpdateAdInsertion(adinsertionID, serviceData)
+	updated, err := r.client.UpdateAdInsertion(ctx, adinsertionID, serviceData)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error updating adserver",
-			"Could not update adserver, unexpected error: "+err.Error(),
-		)
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error updating adserver", "Could not update adserver, unexpected error", err)
+		return
+	}
+	r.applyState(ctx, &resp.Diagnostics, adinsertionID, updated.State, plan.State)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Fetch updated items from GetAdInsertion.
-	service, err := r.client.GetAdInsertion(adinsertionID)
+	service, err := r.client.GetAdInsertion(ctx, adinsertionID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading AdInsertion",
@@ -926,6 +1151,7 @@ func (r *serviceAdInsertionResource) Update(ctx context.Context, req resource.Up
 		State:               types.StringValue(service.State),
 		Tags:                tagsList,
 		EnableAdTranscoding: types.BoolValue(service.EnableAdTranscoding),
+		DeletionProtection:  plan.DeletionProtection,
 		Source: &sourceLiteModel{
 			ID:          types.Int64Value(int64(service.Source.Id)),
 			Name:        types.StringValue(service.Source.Name),
@@ -938,7 +1164,7 @@ func (r *serviceAdInsertionResource) Update(ctx context.Context, req resource.Up
 			ID:         types.Int64Value(int64(service.TranscodingProfile.Id)),
 			Name:       types.StringValue(service.TranscodingProfile.Name),
 			InternalId: types.StringValue(service.TranscodingProfile.InternalId),
-			Content:    types.StringValue(service.TranscodingProfile.Content),
+			Content:    newJSONStringValue(service.TranscodingProfile.Content),
 		},
 	}
 
@@ -954,6 +1180,8 @@ func (r *serviceAdInsertionResource) Update(ctx context.Context, req resource.Up
 			Offset:      types.Int64Value(int64(service.LiveAdPreRoll.Offset)),
 		}
 	}
+	result.VodAdInsertion = flattenVodAdInsertion(service.VodAdInsertion)
+
 	mode := service.LiveAdReplacement.SpotAware.Mode
 	if mode == "" {
 		mode = "disabled"
@@ -1014,6 +1242,10 @@ func (r *serviceAdInsertionResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
+	if !checkDeletionProtection(ctx, req.State, &resp.Diagnostics, "ad insertion service", state.ID.ValueInt64()) {
+		return
+	}
+
 	// Delete existing adserver.
 	_, err := r.client.DeleteAdInsertion(uint(state.ID.ValueInt64()))
 	if err != nil {
@@ -1059,7 +1291,9 @@ type serviceAdInsertionResourceModel struct {
 	AdvancedOptions      *advancedOptionsModel              `tfsdk:"advanced_options"`
 	LiveAdPreRoll        *liveAdPrerollLiteModel            `tfsdk:"live_ad_preroll"`
 	LiveAdReplacement    *liveAdReplacementLiteModel        `tfsdk:"live_ad_replacement"`
+	VodAdInsertion       *vodAdInsertionLiteModel           `tfsdk:"vod_ad_insertion"`
 	EnableAdTranscoding  types.Bool                         `tfsdk:"enable_ad_transcoding"`
+	DeletionProtection   types.Bool                         `tfsdk:"deletion_protection"`
 	ServerSideAdTracking *serverSideAdTrackingModel         `tfsdk:"server_side_ad_tracking"`
 	Source               *sourceLiteModel                   `tfsdk:"source"`
 	TranscodingProfile   *transcodingProfileDataSourceModel `tfsdk:"transcoding_profile"`
@@ -1087,6 +1321,37 @@ type liveAdReplacementLiteModel struct {
 	SpotAware spotAwareModel    `tfsdk:"spot_aware"`
 }
 
+type vodAdInsertionLiteModel struct {
+	AdServer adServerLiteModel `tfsdk:"ad_server"`
+}
+
+// expand returns the VOD ad insertion sent to the API, nil when it is not
+// configured.
+func (m *vodAdInsertionLiteModel) expand() *apiVodAdInsertionInput {
+	if m == nil {
+		return nil
+	}
+	return &apiVodAdInsertionInput{
+		AdServer: &apiRef{Id: uint(m.AdServer.ID.ValueInt64())},
+	}
+}
+
+// flattenVodAdInsertion returns the model of the VOD ad insertion of a
+// service, nil when the service has none.
+func flattenVodAdInsertion(vod apiVodAdInsertion) *vodAdInsertionLiteModel {
+	if vod.AdServer.Id == 0 {
+		return nil
+	}
+	return &vodAdInsertionLiteModel{
+		AdServer: adServerLiteModel{
+			ID:   types.Int64Value(int64(vod.AdServer.Id)),
+			Name: types.StringValue(vod.AdServer.Name),
+			Type: types.StringValue(vod.AdServer.Type),
+			URL:  types.StringValue(vod.AdServer.Url),
+		},
+	}
+}
+
 type adServerLiteModel struct {
 	ID   types.Int64  `tfsdk:"id"`
 	Name types.String `tfsdk:"name"`
@@ -4,12 +4,21 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -21,9 +30,6 @@ var (
 
 func TestAccServiceAdInsertion_Basic(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	slateName := "tf-acc-slate-" + randomSuffix()
 	liveName := "tf-acc-live-" + randomSuffix()
@@ -51,9 +57,6 @@ func TestAccServiceAdInsertion_Basic(t *testing.T) {
 
 func TestAccServiceAdInsertion_UpdateName(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	// Generate unique names for all resources for this test run
 	slateName := "tf-acc-slate-" + randomSuffix()
@@ -88,9 +91,6 @@ func TestAccServiceAdInsertion_UpdateName(t *testing.T) {
 
 func TestAccServiceAdInsertion_UpdateSlate(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	// Generate unique names for every resource
 	initialSlateName := "tf-acc-slate-initial-" + randomSuffix()
@@ -121,9 +121,6 @@ func TestAccServiceAdInsertion_UpdateSlate(t *testing.T) {
 
 func TestAccServiceAdInsertion_UpdateLiveName(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	initialLiveName := "tf-acc-live-initial-" + randomSuffix()
 	updatedLiveName := "tf-acc-live-updated-" + randomSuffix()
@@ -151,9 +148,6 @@ func TestAccServiceAdInsertion_UpdateLiveName(t *testing.T) {
 
 func TestAccServiceAdInsertion_ImportStateAndDrift(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	// Generate unique names to avoid collisions across jobs
 	slateName := "tf-acc-slate-" + randomSuffix()
@@ -181,9 +175,6 @@ func TestAccServiceAdInsertion_ImportStateAndDrift(t *testing.T) {
 
 func TestAccServiceAdInsertion_InvalidSource(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	badID := 999999999
 
 	slateName := "tf-acc-slate-" + randomSuffix()
@@ -198,7 +189,7 @@ func TestAccServiceAdInsertion_InvalidSource(t *testing.T) {
 				Config: testAccServiceAdInsertionConfigWithBadSource(
 					apiKey, slateName, adServerName, serviceName, badID,
 				),
-				ExpectError: regexp.MustCompile(`(?i)403|forbidden|not allowed`),
+				ExpectError: regexp.MustCompile(`(?i)Invalid Ad Insertion Reference|403|forbidden|not allowed`),
 			},
 		},
 	})
@@ -206,9 +197,6 @@ func TestAccServiceAdInsertion_InvalidSource(t *testing.T) {
 
 func TestAccServiceAdInsertion_InvalidAdServer(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	badID := 999999999
 
 	liveName := "tf-acc-live-" + randomSuffix()
@@ -223,7 +211,7 @@ func TestAccServiceAdInsertion_InvalidAdServer(t *testing.T) {
 				Config: testAccServiceAdInsertionConfigWithBadAdServer(
 					apiKey, liveName, slateName, serviceName, badID,
 				),
-				ExpectError: regexp.MustCompile(`(?i)403|forbidden|not allowed`),
+				ExpectError: regexp.MustCompile(`(?i)Invalid Ad Insertion Reference|403|forbidden|not allowed`),
 			},
 		},
 	})
@@ -532,3 +520,399 @@ resource "bpkio_service_ad_insertion" "test" {
 }
 `, apiKey, liveName, LiveURL, slateName, SlateURL, serviceName, badAdServerID)
 }
+
+// Lifecycle against the in-memory fake, including the sources the service
+// references.
+func TestServiceAdInsertionResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	resourceName := "bpkio_service_ad_insertion.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAdInsertionConfigWithName("fake", "slate", "live", "adserver", "service-initial"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "service-initial"),
+					resource.TestCheckResourceAttrPair(resourceName, "source.id", "bpkio_source_live.live", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "live_ad_replacement.gap_filler.id", "bpkio_source_slate.slate", "id"),
+				),
+			},
+			{
+				Config: testAccServiceAdInsertionConfigWithName("fake", "slate", "live", "adserver", "service-updated"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "name", "service-updated"),
+			},
+		},
+	})
+}
+
+func testServiceAdInsertionConfigWithState(state string) string {
+	return strings.Replace(
+		testAccServiceAdInsertionConfigWithName("fake", "slate", "live", "adserver", "service"),
+		`name = "service"`,
+		fmt.Sprintf("name  = \"service\"\n  state = %q", state),
+		1,
+	)
+}
+
+// The state is switched through the state-change calls, and changes made
+// outside of Terraform show up as drift.
+func TestServiceAdInsertionResource_State(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	resourceName := "bpkio_service_ad_insertion.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testServiceAdInsertionConfigWithState("bypassed"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "state", "bypassed"),
+			},
+			{
+				Config: testServiceAdInsertionConfigWithState("paused"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "state", "paused"),
+			},
+			{
+				PreConfig: func() {
+					client.mu.Lock()
+					defer client.mu.Unlock()
+					for id, service := range client.adInsertions {
+						service.State = "enabled"
+						client.adInsertions[id] = service
+					}
+				},
+				Config:             testServiceAdInsertionConfigWithState("paused"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestServiceAdInsertionResource_ApplyState(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	service, err := client.CreateAdInsertion(ctx, apiAdInsertionInput{CreateAdInsertionInput: broadpeakio.CreateAdInsertionInput{Name: "service"}})
+	require.NoError(t, err)
+	r := &serviceAdInsertionResource{client: client}
+
+	var diags diag.Diagnostics
+	require.Equal(t, "enabled", r.applyState(ctx, &diags, service.Id, "enabled", types.StringUnknown()))
+	require.Equal(t, "bypassed", r.applyState(ctx, &diags, service.Id, "enabled", types.StringValue("bypassed")))
+	require.False(t, diags.HasError())
+
+	got, err := client.GetAdInsertion(ctx, service.Id)
+	require.NoError(t, err)
+	require.Equal(t, "bypassed", got.State)
+
+	require.Equal(t, "bypassed", r.applyState(ctx, &diags, service.Id+100, "bypassed", types.StringValue("paused")))
+	require.True(t, diags.HasError())
+	require.Equal(t, path.Root("state"), diags[0].(diag.DiagnosticWithPath).Path())
+}
+
+// stateFailingClient refuses every state change of an ad insertion service.
+type stateFailingClient struct {
+	*fakeClient
+}
+
+func (c *stateFailingClient) SetAdInsertionState(_ context.Context, id uint, _ string) error {
+	return fmt.Errorf("error: 500, body: cannot change the state of service %d", id)
+}
+
+func TestServiceAdInsertionResource_CreateStateFailure(t *testing.T) {
+	ctx := context.Background()
+	client := &stateFailingClient{fakeClient: newFakeClient()}
+	r := &serviceAdInsertionResource{client: client}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, serviceAdInsertionResourceModel{
+		ID:                  types.Int64Unknown(),
+		Name:                types.StringValue("service"),
+		Type:                types.StringUnknown(),
+		URL:                 types.StringUnknown(),
+		CreationDate:        types.StringUnknown(),
+		UpdateDate:          types.StringUnknown(),
+		State:               types.StringValue("paused"),
+		Tags:                types.ListNull(types.StringType),
+		EnableAdTranscoding: types.BoolValue(false),
+		DeletionProtection:  types.BoolValue(false),
+	}).HasError())
+
+	resp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+	require.True(t, resp.Diagnostics.HasError())
+
+	// The service was created: it is in the state, in the state it is
+	// really in, for Terraform to taint it.
+	var state serviceAdInsertionResourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.False(t, state.ID.IsNull())
+	require.Contains(t, client.adInsertions, uint(state.ID.ValueInt64()))
+	require.Equal(t, "enabled", state.State.ValueString())
+}
+
+// testServiceAdInsertionConfigVOD inserts VOD ads into the source whose ID
+// is sourceID, an expression or a literal, with an optional extra block in
+// the service.
+func testServiceAdInsertionConfigVOD(sourceID, extra string) string {
+	return fmt.Sprintf(`
+provider "bpkio" {
+  api_key = "fake"
+}
+
+resource "bpkio_source_asset" "asset" {
+  name = "asset"
+  url  = "https://origin.broadpeak.io/bpk-vod/voddemo/default/5min/tearsofsteel/manifest.m3u8"
+}
+
+resource "bpkio_source_live" "live" {
+  name = "live"
+  url  = "%s"
+}
+
+resource "bpkio_source_adserver" "adserver" {
+  name = "adserver"
+  url  = "%s"
+}
+
+resource "bpkio_service_ad_insertion" "test" {
+  name = "service"
+
+  source = {
+    id = %s
+  }
+
+  vod_ad_insertion = {
+    ad_server = {
+      id = bpkio_source_adserver.adserver.id
+    }
+  }
+%s
+}
+`, LiveURL, AdServerURL, sourceID, extra)
+}
+
+func TestServiceAdInsertionResource_VOD(t *testing.T) {
+	testUnitPreCheck(t)
+
+	resourceName := "bpkio_service_ad_insertion.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: testServiceAdInsertionConfigVOD("bpkio_source_asset.asset.id", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "vod_ad_insertion.ad_server.id", "bpkio_source_adserver.adserver", "id"),
+					resource.TestCheckResourceAttr(resourceName, "vod_ad_insertion.ad_server.name", "adserver"),
+					resource.TestCheckNoResourceAttr(resourceName, "live_ad_replacement"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "transcoding_profile"},
+			},
+		},
+	})
+}
+
+func TestServiceAdInsertionResource_VODWithLiveSource(t *testing.T) {
+	testUnitPreCheck(t)
+
+	// The ID of a live source created in the same configuration is unknown
+	// at plan time, so the source exists beforehand.
+	client := newFakeClient()
+	live, err := client.CreateLive(broadpeakio.LiveInput{Name: "existing", Url: LiveURL})
+	require.NoError(t, err)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config:      testServiceAdInsertionConfigVOD(fmt.Sprint(live.Id), ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`VOD Ad Insertion With Live Source`),
+			},
+		},
+	})
+}
+
+func TestServiceAdInsertionResource_VODWithLiveAdReplacement(t *testing.T) {
+	testUnitPreCheck(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: testServiceAdInsertionConfigVOD("bpkio_source_asset.asset.id", `
+  live_ad_replacement = {
+    ad_server = {
+      id = bpkio_source_adserver.adserver.id
+    }
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting Ad Insertion Configuration`),
+			},
+		},
+	})
+}
+
+func TestServiceAdInsertionResource_CheckReferences(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	live, err := client.CreateLive(broadpeakio.LiveInput{Name: "live", Url: LiveURL})
+	require.NoError(t, err)
+	slate, err := client.CreateSlate(broadpeakio.SlateInput{Name: "slate", Url: SlateURL})
+	require.NoError(t, err)
+	adServer, err := client.CreateAdServer(broadpeakio.AdServerInput{Name: "adserver", Url: AdServerURL})
+	require.NoError(t, err)
+	r := &serviceAdInsertionResource{client: client}
+
+	plan := func(sourceID, adServerID, gapFillerID uint) serviceAdInsertionResourceModel {
+		return serviceAdInsertionResourceModel{
+			Source: &sourceLiteModel{ID: types.Int64Value(int64(sourceID))},
+			LiveAdReplacement: &liveAdReplacementLiteModel{
+				AdServer:  adServerLiteModel{ID: types.Int64Value(int64(adServerID))},
+				GapFiller: gapFillerModel{ID: types.Int64Value(int64(gapFillerID))},
+			},
+		}
+	}
+
+	diags := r.checkReferences(ctx, plan(live.Id, adServer.Id, slate.Id), nil)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	// The ad server and the gap filler are swapped, and the source is a slate.
+	diags = r.checkReferences(ctx, plan(slate.Id, slate.Id, adServer.Id), nil)
+	require.Len(t, diags, 3)
+	var paths []path.Path
+	for _, d := range diags {
+		require.Equal(t, "Invalid Ad Insertion Reference", d.Summary())
+		paths = append(paths, d.(diag.DiagnosticWithPath).Path())
+	}
+	require.Equal(t, []path.Path{
+		path.Root("source").AtName("id"),
+		path.Root("live_ad_replacement").AtName("ad_server").AtName("id"),
+		path.Root("live_ad_replacement").AtName("gap_filler").AtName("id"),
+	}, paths)
+
+	// Unknown IDs are not checked.
+	unknown := plan(live.Id, adServer.Id, slate.Id)
+	unknown.LiveAdReplacement.GapFiller.ID = types.Int64Unknown()
+	unknown.LiveAdReplacement.AdServer.ID = types.Int64Value(int64(slate.Id))
+	diags = r.checkReferences(ctx, unknown, nil)
+	require.Len(t, diags, 1)
+
+	// A VOD service must not use a live source.
+	vod := serviceAdInsertionResourceModel{
+		Source:         &sourceLiteModel{ID: types.Int64Value(int64(live.Id))},
+		VodAdInsertion: &vodAdInsertionLiteModel{AdServer: adServerLiteModel{ID: types.Int64Value(int64(adServer.Id))}},
+	}
+	diags = r.checkReferences(ctx, vod, nil)
+	require.Len(t, diags, 1)
+	require.Equal(t, "VOD Ad Insertion With Live Source", diags[0].Summary())
+}
+
+// untypedClient serves any source or ad server from the endpoints of each
+// type, so that only the type of the answer tells them apart, and counts
+// the calls.
+type untypedClient struct {
+	*fakeClient
+	gets int
+}
+
+func (c *untypedClient) get(id uint) (broadpeakio.Source, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gets++
+	if source, ok := c.sources[id]; ok {
+		return source, nil
+	}
+	if adServer, ok := c.adServers[id]; ok {
+		return broadpeakio.Source{Id: adServer.Id, Type: adServer.Type, Name: adServer.Name}, nil
+	}
+	return broadpeakio.Source{}, fakeNotFound("source", id)
+}
+
+func (c *untypedClient) GetLive(id uint) (broadpeakio.Source, error)  { return c.get(id) }
+func (c *untypedClient) GetSlate(id uint) (broadpeakio.Source, error) { return c.get(id) }
+func (c *untypedClient) GetAdServer(id uint) (broadpeakio.AdServer, error) {
+	source, err := c.get(id)
+	return broadpeakio.AdServer{Id: source.Id, Type: source.Type, Name: source.Name}, err
+}
+
+func TestServiceAdInsertionResource_CheckReferencesType(t *testing.T) {
+	ctx := context.Background()
+	client := &untypedClient{fakeClient: newFakeClient()}
+	live, err := client.CreateLive(broadpeakio.LiveInput{Name: "live", Url: LiveURL})
+	require.NoError(t, err)
+	slate, err := client.CreateSlate(broadpeakio.SlateInput{Name: "slate", Url: SlateURL})
+	require.NoError(t, err)
+	adServer, err := client.CreateAdServer(broadpeakio.AdServerInput{Name: "adserver", Url: AdServerURL})
+	require.NoError(t, err)
+	r := &serviceAdInsertionResource{client: client}
+
+	plan := func(sourceID, adServerID, gapFillerID uint) serviceAdInsertionResourceModel {
+		return serviceAdInsertionResourceModel{
+			Source: &sourceLiteModel{ID: types.Int64Value(int64(sourceID))},
+			LiveAdReplacement: &liveAdReplacementLiteModel{
+				AdServer:  adServerLiteModel{ID: types.Int64Value(int64(adServerID))},
+				GapFiller: gapFillerModel{ID: types.Int64Value(int64(gapFillerID))},
+			},
+		}
+	}
+
+	// The objects exist, but their type is not the expected one.
+	diags := r.checkReferences(ctx, plan(slate.Id, slate.Id, adServer.Id), nil)
+	require.Len(t, diags, 3)
+	for _, d := range diags {
+		require.Equal(t, "Invalid Ad Insertion Reference", d.Summary())
+	}
+
+	// Only the references that change are fetched.
+	prior := plan(live.Id, adServer.Id, slate.Id)
+	client.gets = 0
+	require.False(t, r.checkReferences(ctx, prior, &prior).HasError())
+	require.Zero(t, client.gets)
+
+	diags = r.checkReferences(ctx, plan(live.Id, slate.Id, slate.Id), &prior)
+	require.Len(t, diags, 1)
+	require.Equal(t, path.Root("live_ad_replacement").AtName("ad_server").AtName("id"), diags[0].(diag.DiagnosticWithPath).Path())
+	require.Equal(t, 1, client.gets)
+}
+
+// A reference to the wrong kind of object fails the plan, before anything is
+// created.
+func TestServiceAdInsertionResource_WrongReferenceType(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	config := strings.Replace(
+		testAccServiceAdInsertionConfigWithName("fake", "slate", "live", "adserver", "service"),
+		"id = bpkio_source_adserver.adserver.id",
+		"id = bpkio_source_slate.slate.id",
+		1,
+	)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAdInsertionConfigWithName("fake", "slate", "live", "adserver", "service"),
+			},
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Ad Insertion Reference`),
+			},
+		},
+	})
+}
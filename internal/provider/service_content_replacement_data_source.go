@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &serviceContentReplacementDataSource{}
+	_ datasource.DataSourceWithConfigure = &serviceContentReplacementDataSource{}
+)
+
+// serviceContentReplacementDataSource is the data source implementation.
+type serviceContentReplacementDataSource struct {
+	client apiClient
+}
+
+// NewServiceContentReplacementDataSource is a helper function to simplify the provider implementation.
+func NewServiceContentReplacementDataSource() datasource.DataSource {
+	return &serviceContentReplacementDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *serviceContentReplacementDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *serviceContentReplacementDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_content_replacement"
+}
+
+// computedSourceReferenceAttribute is the data source counterpart of
+// sourceReferenceAttribute.
+func computedSourceReferenceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Computed: true,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				Computed: true,
+			},
+			"url": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Schema defines the schema for the data source.
+func (d *serviceContentReplacementDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a content replacement service.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Required:    true,
+				Description: "ID of the content replacement service.",
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				Computed: true,
+			},
+			"url": schema.StringAttribute{
+				Computed: true,
+			},
+			"creation_date": schema.StringAttribute{
+				Computed: true,
+			},
+			"update_date": schema.StringAttribute{
+				Computed: true,
+			},
+			"state": schema.StringAttribute{
+				Computed: true,
+			},
+			"tags": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"source":      computedSourceReferenceAttribute(),
+			"replacement": computedSourceReferenceAttribute(),
+			"transcoding_profile": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						Computed: true,
+					},
+					"name": schema.StringAttribute{
+						Computed: true,
+					},
+					"internal_id": schema.StringAttribute{
+						Computed: true,
+					},
+					"content": schema.StringAttribute{
+						CustomType: jsonStringType{},
+						Computed:   true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *serviceContentReplacementDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config serviceContentReplacementModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := d.client.GetContentReplacement(ctx, uint(config.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Content-Replacement",
+			fmt.Sprintf("Content replacement service with ID %d not found (%s)", config.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	state, diags := flattenContentReplacement(ctx, service)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,402 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &serviceContentReplacementResource{}
+	_ resource.ResourceWithConfigure   = &serviceContentReplacementResource{}
+	_ resource.ResourceWithImportState = &serviceContentReplacementResource{}
+)
+
+// NewServiceContentReplacementResource is a helper function to simplify the provider implementation.
+func NewServiceContentReplacementResource() resource.Resource {
+	return &serviceContentReplacementResource{}
+}
+
+// serviceContentReplacementResource is the resource implementation.
+type serviceContentReplacementResource struct {
+	client apiClient
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *serviceContentReplacementResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *serviceContentReplacementResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_content_replacement"
+}
+
+// sourceReferenceAttribute is a reference to a source by ID, completed with
+// the details of the source returned by the API.
+func sourceReferenceAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Required:    true,
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Required:    true,
+				Description: "ID of the source.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the source.",
+			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the source.",
+			},
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the source.",
+			},
+		},
+	}
+}
+
+// Schema defines the schema for the resource.
+func (r *serviceContentReplacementResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a content replacement service, which replaces parts of a live source with a slate or an asset (see https://developers.broadpeak.io/reference/contentreplacementcontroller_create_v1).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "ID of the content replacement service.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the content replacement service.",
+			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the service, always `content-replacement`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the content replacement service. This is the endpoint where the service can be accessed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "Creation date of the content replacement service.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"update_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "Date of the last update of the content replacement service.",
+			},
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the content replacement service, as reported by the API. Possible values are 'enabled', 'paused', or 'bypassed'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Tags for the content replacement service.",
+				ElementType: types.StringType,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+			},
+			"source":      sourceReferenceAttribute("The live source whose content is replaced."),
+			"replacement": sourceReferenceAttribute("The slate or asset source played instead of the replaced content."),
+			"transcoding_profile": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						Required:    true,
+						Description: "ID of the transcoding profile.",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "Name of the transcoding profile.",
+					},
+					"internal_id": schema.StringAttribute{
+						Computed:    true,
+						Description: "Internal ID of the transcoding profile.",
+					},
+					"content": schema.StringAttribute{
+						CustomType:  jsonStringType{},
+						Computed:    true,
+						Description: "Content of the transcoding profile.",
+					},
+				},
+				Optional:    true,
+				Description: "Transcoding profile used for the replacement content.",
+			},
+		},
+	}
+}
+
+// expand builds the API input from the Terraform plan.
+func (m serviceContentReplacementModel) expand(ctx context.Context) (apiContentReplacementInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	input := apiContentReplacementInput{
+		Name:        m.Name.ValueString(),
+		Tags:        []string{},
+		Source:      apiRef{Id: uint(m.Source.ID.ValueInt64())},
+		Replacement: apiRef{Id: uint(m.Replacement.ID.ValueInt64())},
+	}
+	if !m.Tags.IsNull() && !m.Tags.IsUnknown() {
+		diags.Append(m.Tags.ElementsAs(ctx, &input.Tags, false)...)
+	}
+	if m.TranscodingProfile != nil {
+		input.TranscodingProfile = &apiRef{Id: uint(m.TranscodingProfile.ID.ValueInt64())}
+	}
+	return input, diags
+}
+
+// flattenSourceReference converts a source expanded by the API into its
+// Terraform model.
+func flattenSourceReference(source apiSourceRef) *sourceReferenceModel {
+	return &sourceReferenceModel{
+		ID:   types.Int64Value(int64(source.Id)),
+		Name: types.StringValue(source.Name),
+		Type: types.StringValue(source.Type),
+		URL:  types.StringValue(source.Url),
+	}
+}
+
+// flattenContentReplacement converts an API content replacement service into
+// its Terraform model.
+func flattenContentReplacement(ctx context.Context, service apiContentReplacement) (serviceContentReplacementModel, diag.Diagnostics) {
+	if service.Tags == nil {
+		service.Tags = []string{}
+	}
+	tags, diags := types.ListValueFrom(ctx, types.StringType, service.Tags)
+
+	state := serviceContentReplacementModel{
+		ID:           types.Int64Value(int64(service.Id)),
+		Name:         types.StringValue(service.Name),
+		Type:         types.StringValue(service.Type),
+		URL:          types.StringValue(service.Url),
+		CreationDate: types.StringValue(service.CreationDate),
+		UpdateDate:   types.StringValue(service.UpdateDate),
+		State:        types.StringValue(service.State),
+		Tags:         tags,
+		Source:       flattenSourceReference(service.Source),
+		Replacement:  flattenSourceReference(service.Replacement),
+	}
+	if service.TranscodingProfile.Id != 0 {
+		state.TranscodingProfile = &transcodingProfileDataSourceModel{
+			ID:         types.Int64Value(int64(service.TranscodingProfile.Id)),
+			Name:       types.StringValue(service.TranscodingProfile.Name),
+			InternalId: types.StringValue(service.TranscodingProfile.InternalId),
+			Content:    newJSONStringValue(service.TranscodingProfile.Content),
+		}
+	}
+	return state, diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *serviceContentReplacementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	//--------------------------------------------------------------------.
+	// 1. Decode plan.
+	//--------------------------------------------------------------------.
+	var plan serviceContentReplacementModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	//--------------------------------------------------------------------.
+	// 2. Convert plan -> API input.
+	//--------------------------------------------------------------------.
+	input, diags := plan.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	//--------------------------------------------------------------------.
+	// 3. Call Broadpeak API.
+	//--------------------------------------------------------------------.
+	service, err := r.client.CreateContentReplacement(ctx, input)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error creating Content-Replacement", "Could not create Content-Replacement", err)
+		return
+	}
+
+	//--------------------------------------------------------------------.
+	// 4. Save state.
+	//--------------------------------------------------------------------.
+	state, diags := flattenContentReplacement(ctx, service)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *serviceContentReplacementResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state serviceContentReplacementModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := r.client.GetContentReplacement(ctx, uint(state.ID.ValueInt64()))
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Content replacement service not found, removing from state", map[string]interface{}{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Content-Replacement",
+			fmt.Sprintf("Could not read content replacement service ID %d: %s", state.ID.ValueInt64(), err),
+		)
+		return
+	}
+
+	state, diags = flattenContentReplacement(ctx, service)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *serviceContentReplacementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan serviceContentReplacementModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := plan.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID := uint(plan.ID.ValueInt64())
+	tflog.Debug(ctx, "Update - Update Doc sent to BPKIO", map[string]interface{}{"id": serviceID, "updates": input})
+
+	service, err := r.client.UpdateContentReplacement(ctx, serviceID, input)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error updating Content-Replacement", fmt.Sprintf("Could not update content replacement service ID %d", serviceID), err)
+		return
+	}
+
+	state, diags := flattenContentReplacement(ctx, service)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *serviceContentReplacementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state serviceContentReplacementModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteContentReplacement(ctx, uint(state.ID.ValueInt64()))
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Content-Replacement",
+			"Could not delete content replacement service, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource state from the ID.
+func (r *serviceContentReplacementResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing content replacement service",
+			fmt.Sprintf("Invalid ID format: %s. Expected a numeric ID. Error: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// serviceContentReplacementModel maps the schema of the content replacement
+// resource and data source.
+type serviceContentReplacementModel struct {
+	ID                 types.Int64                        `tfsdk:"id"`
+	Name               types.String                       `tfsdk:"name"`
+	Type               types.String                       `tfsdk:"type"`
+	URL                types.String                       `tfsdk:"url"`
+	CreationDate       types.String                       `tfsdk:"creation_date"`
+	UpdateDate         types.String                       `tfsdk:"update_date"`
+	State              types.String                       `tfsdk:"state"`
+	Tags               types.List                         `tfsdk:"tags"`
+	Source             *sourceReferenceModel              `tfsdk:"source"`
+	Replacement        *sourceReferenceModel              `tfsdk:"replacement"`
+	TranscodingProfile *transcodingProfileDataSourceModel `tfsdk:"transcoding_profile"`
+}
+
+// sourceReferenceModel maps a reference to a source.
+type sourceReferenceModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+	URL  types.String `tfsdk:"url"`
+}
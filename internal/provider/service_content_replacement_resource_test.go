@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServiceContentReplacement_Basic(t *testing.T) {
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_service_content_replacement.test"
+	name := "tf-acc-test-cr-" + randomSuffix()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceContentReplacementConfig(apiKey, name, `["tf-acc"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "type", "content-replacement"),
+					resource.TestCheckResourceAttr(resourceName, "state", "enabled"),
+					resource.TestCheckResourceAttrPair(resourceName, "source.id", "bpkio_source_live.live", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "replacement.id", "bpkio_source_slate.slate", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "url"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccServiceContentReplacementConfig(apiKey, name, tags string) string {
+	return fmt.Sprintf(`
+provider "bpkio" {
+  api_key = "%[1]s"
+}
+
+resource "bpkio_source_live" "live" {
+  name = "%[2]s-live"
+  url  = "https://origin.broadpeak.io/bpk-tv/bpkiofficial/hlsv3/index.m3u8"
+}
+
+resource "bpkio_source_slate" "slate" {
+  name = "%[2]s-slate"
+  url  = "http://commondatastorage.googleapis.com/gtv-videos-bucket/sample/ForBiggerEscapes.mp4"
+}
+
+resource "bpkio_service_content_replacement" "test" {
+  name = "%[2]s"
+  tags = %[3]s
+
+  source = {
+    id = bpkio_source_live.live.id
+  }
+
+  replacement = {
+    id = bpkio_source_slate.slate.id
+  }
+}
+
+data "bpkio_service_content_replacement" "test" {
+  id = bpkio_service_content_replacement.test.id
+}
+`, apiKey, name, tags)
+}
+
+func TestServiceContentReplacementResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	resourceName := "bpkio_service_content_replacement.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceContentReplacementConfig("fake", "cr", `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "content-replacement"),
+					resource.TestCheckResourceAttr(resourceName, "state", "enabled"),
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "replacement.type", "slate"),
+					resource.TestCheckResourceAttrPair("data.bpkio_service_content_replacement.test", "url", resourceName, "url"),
+				),
+			},
+			{
+				Config: testAccServiceContentReplacementConfig("fake", "cr", `["sports"]`),
+				Check:  resource.TestCheckResourceAttr(resourceName, "tags.0", "sports"),
+			},
+			{
+				// A service paused outside of Terraform is refreshed without
+				// a diff.
+				PreConfig: func() {
+					client.mu.Lock()
+					defer client.mu.Unlock()
+					for id, service := range client.contentReplacements {
+						service.State = "paused"
+						client.contentReplacements[id] = service
+					}
+				},
+				Config: testAccServiceContentReplacementConfig("fake", "cr", `["sports"]`),
+				Check:  resource.TestCheckResourceAttr(resourceName, "state", "paused"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+resource "bpkio_service_content_replacement" "test" {
+  name        = "cr"
+  source      = { id = 999 }
+  replacement = { id = 999 }
+}
+`,
+				ExpectError: regexp.MustCompile(`must reference an existing`),
+			},
+		},
+	})
+}
@@ -0,0 +1,389 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &serviceSlotResource{}
+	_ resource.ResourceWithConfigure   = &serviceSlotResource{}
+	_ resource.ResourceWithImportState = &serviceSlotResource{}
+	_ resource.ResourceWithModifyPlan  = &serviceSlotResource{}
+)
+
+// slotMethods are the API calls managing the slots of one type of service.
+type slotMethods struct {
+	create func(c apiClient, ctx context.Context, serviceID uint, input apiSlotInput) (apiSlot, error)
+	get    func(c apiClient, ctx context.Context, serviceID, id uint) (apiSlot, error)
+	update func(c apiClient, ctx context.Context, serviceID, id uint, input apiSlotInput) (apiSlot, error)
+	delete func(c apiClient, ctx context.Context, serviceID, id uint) error
+}
+
+// serviceSlotResource is the implementation shared by the slot resources of
+// the services that are driven by a schedule.
+type serviceSlotResource struct {
+	client apiClient
+
+	// typeName is the resource type name without the provider prefix.
+	typeName string
+	// service names the type of service in descriptions and messages.
+	service string
+	// replacement describes the sources that a slot can play.
+	replacement string
+	api         slotMethods
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *serviceSlotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *serviceSlotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.typeName
+}
+
+// Schema defines the schema for the resource.
+func (r *serviceSlotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Manages a slot of a %s service, which plays a replacement source for a period of time. "+
+			"Slots that have ended stay in the state with `ended` set and can no longer be changed, as the API rejects slots in the past. "+
+			"Remove them from the configuration when they are no longer needed: they leave the state but are kept by the service.", r.service),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "ID of the slot.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_id": schema.Int64Attribute{
+				Required:    true,
+				Description: fmt.Sprintf("ID of the %s service the slot belongs to. Changing it forces a new slot.", r.service),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the slot.",
+			},
+			"start_time": schema.StringAttribute{
+				Required:    true,
+				Description: "Start of the slot, as an RFC3339 timestamp such as `2025-06-01T20:00:00Z`.",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"end_time": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "End of the slot, as an RFC3339 timestamp. Exactly one of `end_time` and `duration` must be set.",
+				Validators: []validator.String{
+					rfc3339Validator{},
+					stringvalidator.ExactlyOneOf(path.MatchRoot("duration")),
+				},
+			},
+			"duration": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Duration of the slot (in seconds). Exactly one of `end_time` and `duration` must be set.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"replacement": sourceReferenceAttribute(r.replacement),
+			"category": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						Required:    true,
+						Description: "ID of the category.",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "Name of the category.",
+					},
+				},
+				Optional:    true,
+				Description: "Category of the slot.",
+			},
+			"ended": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the slot is over. An ended slot can no longer be changed.",
+			},
+		},
+	}
+}
+
+// expand builds the API input from the Terraform plan. Only the one of end
+// time and duration that is configured is known in the plan.
+func (m serviceSlotModel) expand() apiSlotInput {
+	input := apiSlotInput{
+		Name:        m.Name.ValueString(),
+		StartTime:   m.StartTime.ValueString(),
+		Replacement: apiRef{Id: uint(m.Replacement.ID.ValueInt64())},
+	}
+	if !m.EndTime.IsNull() && !m.EndTime.IsUnknown() {
+		input.EndTime = m.EndTime.ValueString()
+	} else {
+		input.Duration = uint(m.Duration.ValueInt64())
+	}
+	if m.Category != nil {
+		input.Category = &apiRef{Id: uint(m.Category.ID.ValueInt64())}
+	}
+	return input
+}
+
+// sameInstant returns prior when it denotes the same instant as value, so
+// that the API normalising offsets or fractional seconds is not reported as
+// a change.
+func sameInstant(prior types.String, value string) types.String {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.StringValue(value)
+	}
+	a, errA := time.Parse(time.RFC3339, prior.ValueString())
+	b, errB := time.Parse(time.RFC3339, value)
+	if errA == nil && errB == nil && a.Equal(b) {
+		return prior
+	}
+	return types.StringValue(value)
+}
+
+// flattenSlot converts an API slot of the given service into its Terraform
+// model. Timestamps keep the spelling of prior when they did not change.
+func flattenSlot(serviceID types.Int64, slot apiSlot, prior serviceSlotModel) serviceSlotModel {
+	state := serviceSlotModel{
+		ID:          types.Int64Value(int64(slot.Id)),
+		ServiceID:   serviceID,
+		Name:        types.StringValue(slot.Name),
+		StartTime:   sameInstant(prior.StartTime, slot.StartTime),
+		EndTime:     sameInstant(prior.EndTime, slot.EndTime),
+		Duration:    types.Int64Value(int64(slot.Duration)),
+		Replacement: flattenSourceReference(slot.Replacement),
+	}
+	state.Ended = types.BoolValue(state.ended(time.Now()))
+	if slot.Category != nil && slot.Category.Id != 0 {
+		state.Category = &slotCategoryModel{
+			ID:   types.Int64Value(int64(slot.Category.Id)),
+			Name: types.StringValue(slot.Category.Name),
+		}
+	}
+	return state
+}
+
+// ended reports whether the slot is over at now. Slots whose end cannot be
+// determined are considered running.
+func (m serviceSlotModel) ended(now time.Time) bool {
+	if end, err := time.Parse(time.RFC3339, m.EndTime.ValueString()); err == nil {
+		return !end.After(now)
+	}
+	start, err := time.Parse(time.RFC3339, m.StartTime.ValueString())
+	if err != nil || m.Duration.IsNull() || m.Duration.IsUnknown() {
+		return false
+	}
+	return !start.Add(time.Duration(m.Duration.ValueInt64()) * time.Second).After(now)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *serviceSlotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan serviceSlotModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID := uint(plan.ServiceID.ValueInt64())
+	slot, err := r.api.create(r.client, ctx, serviceID, plan.expand())
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error creating Slot", fmt.Sprintf("Could not create slot on %s service ID %d", r.service, serviceID), err)
+		return
+	}
+
+	diags = resp.State.Set(ctx, flattenSlot(plan.ServiceID, slot, plan))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *serviceSlotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state serviceSlotModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logFields := map[string]interface{}{"service_id": state.ServiceID.ValueInt64(), "id": state.ID.ValueInt64()}
+	slot, err := r.api.get(r.client, ctx, uint(state.ServiceID.ValueInt64()), uint(state.ID.ValueInt64()))
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Slot not found, removing from state", logFields)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Slot",
+			fmt.Sprintf("Could not read slot ID %d of %s service ID %d: %s", state.ID.ValueInt64(), r.service, state.ServiceID.ValueInt64(), err),
+		)
+		return
+	}
+
+	state = flattenSlot(state.ServiceID, slot, state)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ModifyPlan rejects changes to a slot that has ended, which the API would
+// refuse, so that the plan does not fail halfway through the apply.
+func (r *serviceSlotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Creations, destructions and unchanged slots are always allowed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var state serviceSlotModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !state.ended(time.Now()) {
+		return
+	}
+	resp.Diagnostics.AddError(
+		"Slot Has Ended",
+		fmt.Sprintf("Slot ID %d of %s service ID %d is over and can no longer be changed or replaced. "+
+			"Restore its previous settings, or remove it from the configuration: it then leaves the state and is kept by the service.",
+			state.ID.ValueInt64(), r.service, state.ServiceID.ValueInt64()),
+	)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *serviceSlotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan serviceSlotModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID, slotID := uint(plan.ServiceID.ValueInt64()), uint(plan.ID.ValueInt64())
+	input := plan.expand()
+	tflog.Debug(ctx, "Update - Update Doc sent to BPKIO", map[string]interface{}{"service_id": serviceID, "id": slotID, "updates": input})
+
+	slot, err := r.api.update(r.client, ctx, serviceID, slotID, input)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error updating Slot", fmt.Sprintf("Could not update slot ID %d of %s service ID %d", slotID, r.service, serviceID), err)
+		return
+	}
+
+	diags = resp.State.Set(ctx, flattenSlot(plan.ServiceID, slot, plan))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+// The API may refuse to delete slots that are over, which is not an error
+// since they no longer affect the service.
+func (r *serviceSlotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state serviceSlotModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.api.delete(r.client, ctx, uint(state.ServiceID.ValueInt64()), uint(state.ID.ValueInt64()))
+	if err == nil || isNotFound(err) {
+		return
+	}
+	if state.ended(time.Now()) {
+		tflog.Info(ctx, "Slot has ended, removing from state", map[string]interface{}{"id": state.ID.ValueInt64(), "error": err.Error()})
+		return
+	}
+	resp.Diagnostics.AddError(
+		"Error Deleting Slot",
+		"Could not delete slot, unexpected error: "+err.Error(),
+	)
+}
+
+// parseSlotImportID splits a `serviceId/slotId` import ID.
+func parseSlotImportID(id string) (int64, int64, error) {
+	serviceID, slotID, ok := strings.Cut(id, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected an ID of the form serviceId/slotId")
+	}
+	service, err := strconv.ParseInt(serviceID, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid service ID: %w", err)
+	}
+	slot, err := strconv.ParseInt(slotID, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid slot ID: %w", err)
+	}
+	return service, slot, nil
+}
+
+// ImportState imports the resource state from a `serviceId/slotId` ID.
+func (r *serviceSlotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serviceID, slotID, err := parseSlotImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing slot",
+			fmt.Sprintf("Invalid ID format: %s. %s.", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_id"), serviceID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), slotID)...)
+}
+
+// serviceSlotModel maps the schema of the slot resources.
+type serviceSlotModel struct {
+	ID          types.Int64           `tfsdk:"id"`
+	ServiceID   types.Int64           `tfsdk:"service_id"`
+	Name        types.String          `tfsdk:"name"`
+	StartTime   types.String          `tfsdk:"start_time"`
+	EndTime     types.String          `tfsdk:"end_time"`
+	Duration    types.Int64           `tfsdk:"duration"`
+	Replacement *sourceReferenceModel `tfsdk:"replacement"`
+	Category    *slotCategoryModel    `tfsdk:"category"`
+	Ended       types.Bool            `tfsdk:"ended"`
+}
+
+type slotCategoryModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
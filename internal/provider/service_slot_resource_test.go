@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSlotImportID(t *testing.T) {
+	service, slot, err := parseSlotImportID("12/345")
+	require.NoError(t, err)
+	require.Equal(t, int64(12), service)
+	require.Equal(t, int64(345), slot)
+
+	for _, id := range []string{"345", "12/", "/345", "a/345", "12/b", "12/345/6"} {
+		_, _, err := parseSlotImportID(id)
+		require.Error(t, err, id)
+	}
+}
+
+func TestServiceSlotModel_Ended(t *testing.T) {
+	now := time.Date(2025, 6, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		model serviceSlotModel
+		want  bool
+	}{
+		{
+			name:  "end time passed",
+			model: serviceSlotModel{StartTime: types.StringValue("2025-06-01T18:00:00Z"), EndTime: types.StringValue("2025-06-01T19:00:00Z")},
+			want:  true,
+		},
+		{
+			name:  "end time in another zone",
+			model: serviceSlotModel{StartTime: types.StringValue("2025-06-01T20:00:00+02:00"), EndTime: types.StringValue("2025-06-01T21:30:00+02:00")},
+			want:  true,
+		},
+		{
+			name:  "running",
+			model: serviceSlotModel{StartTime: types.StringValue("2025-06-01T19:00:00Z"), EndTime: types.StringValue("2025-06-01T21:00:00Z")},
+		},
+		{
+			name:  "duration passed",
+			model: serviceSlotModel{StartTime: types.StringValue("2025-06-01T19:00:00Z"), EndTime: types.StringUnknown(), Duration: types.Int64Value(3600)},
+			want:  true,
+		},
+		{
+			name:  "duration running",
+			model: serviceSlotModel{StartTime: types.StringValue("2025-06-01T19:00:00Z"), EndTime: types.StringNull(), Duration: types.Int64Value(3601)},
+		},
+		{
+			name:  "unknown end",
+			model: serviceSlotModel{StartTime: types.StringValue("2025-06-01T19:00:00Z"), EndTime: types.StringNull(), Duration: types.Int64Unknown()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.model.ended(now))
+		})
+	}
+}
+
+func TestSameInstant(t *testing.T) {
+	prior := types.StringValue("2025-06-01T22:00:00+02:00")
+	require.Equal(t, prior, sameInstant(prior, "2025-06-01T20:00:00Z"))
+	require.Equal(t, types.StringValue("2025-06-01T21:00:00Z"), sameInstant(prior, "2025-06-01T21:00:00Z"))
+	require.Equal(t, types.StringValue("2025-06-01T20:00:00Z"), sameInstant(types.StringUnknown(), "2025-06-01T20:00:00Z"))
+}
+
+func TestServiceSlotResource_Ended(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	live, err := client.CreateLive(broadpeakio.LiveInput{Name: "live", Url: LiveURL})
+	require.NoError(t, err)
+	slate, err := client.CreateSlate(broadpeakio.SlateInput{Name: "slate", Url: SlateURL})
+	require.NoError(t, err)
+	service, err := client.CreateVirtualChannel(ctx, apiVirtualChannelInput{Name: "vc", BaseLive: apiRef{Id: live.Id}})
+	require.NoError(t, err)
+	slot, err := client.CreateVirtualChannelSlot(ctx, service.Id, apiSlotInput{
+		StartTime:   time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		Duration:    600,
+		Replacement: apiRef{Id: slate.Id},
+	})
+	require.NoError(t, err)
+	client.endSlots()
+
+	r := NewVirtualChannelSlotResource().(*serviceSlotResource)
+	r.client = client
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	prior := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, prior.Set(ctx, serviceSlotModel{
+		ID:        types.Int64Value(int64(slot.Id)),
+		ServiceID: types.Int64Value(int64(service.Id)),
+		Duration:  types.Int64Value(600),
+	}).HasError())
+	readResp := fwresource.ReadResponse{State: prior}
+	r.Read(ctx, fwresource.ReadRequest{State: prior}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	var state serviceSlotModel
+	require.False(t, readResp.State.Get(ctx, &state).HasError())
+	require.True(t, state.Ended.ValueBool(), "an ended slot is kept in the state")
+
+	modifyPlan := func(plan serviceSlotModel) fwresource.ModifyPlanResponse {
+		p := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, p.Set(ctx, plan).HasError())
+		resp := fwresource.ModifyPlanResponse{Plan: p}
+		r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{State: readResp.State, Plan: p}, &resp)
+		return resp
+	}
+	require.False(t, modifyPlan(state).Diagnostics.HasError(), "an unchanged ended slot plans no change")
+
+	changed := state
+	changed.Duration = types.Int64Value(1200)
+	diags := modifyPlan(changed).Diagnostics
+	require.True(t, diags.HasError())
+	require.Equal(t, "Slot Has Ended", diags[0].Summary())
+
+	var deleteResp fwresource.DeleteResponse
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "removing an ended slot from the configuration succeeds")
+	_, err = client.GetVirtualChannelSlot(ctx, service.Id, slot.Id)
+	require.NoError(t, err, "the service keeps the ended slot")
+}
+
+func TestAccVirtualChannelSlot_Basic(t *testing.T) {
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_virtual_channel_slot.test"
+	name := "tf-acc-test-slot-" + randomSuffix()
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Hour)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVirtualChannelConfig(apiKey, name, `[]`) + testAccVirtualChannelSlotConfig(name, start, "duration = 600"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "service_id", "bpkio_service_virtual_channel.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "duration", "600"),
+					resource.TestCheckResourceAttr(resourceName, "end_time", start.Add(10*time.Minute).Format(time.RFC3339)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testSlotImportID(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVirtualChannelSlotConfig(name string, start time.Time, end string) string {
+	return fmt.Sprintf(`
+resource "bpkio_virtual_channel_slot" "test" {
+  service_id = bpkio_service_virtual_channel.test.id
+  name       = "%s"
+  start_time = "%s"
+  %s
+
+  replacement = {
+    id = bpkio_source_slate.slate.id
+  }
+}
+`, name, start.Format(time.RFC3339), end)
+}
+
+// testSlotImportID returns the `serviceId/slotId` import ID of the slot
+// resourceName.
+func testSlotImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+		return rs.Primary.Attributes["service_id"] + "/" + rs.Primary.ID, nil
+	}
+}
+
+func TestVirtualChannelSlotResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	resourceName := "bpkio_virtual_channel_slot.test"
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Hour)
+	service := testAccServiceVirtualChannelConfig("fake", "vc", `[]`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: service + testAccVirtualChannelSlotConfig("slot", start, fmt.Sprintf("end_time = %q", start.Add(time.Hour).Format(time.RFC3339))),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "duration", "3600"),
+					resource.TestCheckResourceAttr(resourceName, "replacement.type", "slate"),
+					resource.TestCheckNoResourceAttr(resourceName, "category"),
+				),
+			},
+			{
+				Config: service + testAccVirtualChannelSlotConfig("slot", start, "duration = 600\n  category = { id = 7 }"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "end_time", start.Add(10*time.Minute).Format(time.RFC3339)),
+					resource.TestCheckResourceAttr(resourceName, "category.name", "category-7"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testSlotImportID(resourceName),
+				ImportStateVerify: true,
+			},
+			{
+				// Once over, the slot can be removed from the configuration,
+				// which does not delete it from the service.
+				PreConfig: client.endSlots,
+				Config:    service,
+				Check: func(*terraform.State) error {
+					if len(client.slots) != 1 {
+						return fmt.Errorf("expected the ended slot to be kept by the service, got %d slots", len(client.slots))
+					}
+					return nil
+				},
+			},
+			{
+				Config:      service + testAccVirtualChannelSlotConfig("slot", start, `end_time = "tomorrow"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Timestamp`),
+			},
+			{
+				Config:      service + testAccVirtualChannelSlotConfig("slot", start, ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestContentReplacementSlotResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	resourceName := "bpkio_content_replacement_slot.test"
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Hour)
+	service := testAccServiceContentReplacementConfig("fake", "cr", `[]`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config:      service + testContentReplacementSlotConfig(start, "bpkio_source_live.live.id"),
+				ExpectError: regexp.MustCompile(`replacement must reference an existing slate, asset source`),
+			},
+			{
+				Config: service + testContentReplacementSlotConfig(start, "bpkio_source_slate.slate.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "service_id", "bpkio_service_content_replacement.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "end_time", start.Add(30*time.Minute).Format(time.RFC3339)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testSlotImportID(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testContentReplacementSlotConfig(start time.Time, replacement string) string {
+	return fmt.Sprintf(`
+resource "bpkio_content_replacement_slot" "test" {
+  service_id = bpkio_service_content_replacement.test.id
+  start_time = %q
+  duration   = 1800
+
+  replacement = {
+    id = %s
+  }
+}
+`, start.Format(time.RFC3339), replacement)
+}
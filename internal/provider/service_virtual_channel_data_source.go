@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &serviceVirtualChannelDataSource{}
+	_ datasource.DataSourceWithConfigure = &serviceVirtualChannelDataSource{}
+)
+
+// serviceVirtualChannelDataSource is the data source implementation.
+type serviceVirtualChannelDataSource struct {
+	client apiClient
+}
+
+// NewServiceVirtualChannelDataSource is a helper function to simplify the provider implementation.
+func NewServiceVirtualChannelDataSource() datasource.DataSource {
+	return &serviceVirtualChannelDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *serviceVirtualChannelDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *serviceVirtualChannelDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_virtual_channel"
+}
+
+// Schema defines the schema for the data source.
+func (d *serviceVirtualChannelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a virtual channel service.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Required:    true,
+				Description: "ID of the virtual channel service.",
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				Computed: true,
+			},
+			"url": schema.StringAttribute{
+				Computed: true,
+			},
+			"creation_date": schema.StringAttribute{
+				Computed: true,
+			},
+			"update_date": schema.StringAttribute{
+				Computed: true,
+			},
+			"state": schema.StringAttribute{
+				Computed: true,
+			},
+			"tags": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"base_live": computedSourceReferenceAttribute(),
+			"ad_break_insertion": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"ad_server":  computedSourceReferenceAttribute(),
+					"gap_filler": computedSourceReferenceAttribute(),
+				},
+			},
+			"live_ad_preroll": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"ad_server": computedSourceReferenceAttribute(),
+					"max_duration": schema.Int64Attribute{
+						Computed: true,
+					},
+					"offset": schema.Int64Attribute{
+						Computed: true,
+					},
+				},
+			},
+			"enable_ad_transcoding": schema.BoolAttribute{
+				Computed: true,
+			},
+			"transcoding_profile": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						Computed: true,
+					},
+					"name": schema.StringAttribute{
+						Computed: true,
+					},
+					"internal_id": schema.StringAttribute{
+						Computed: true,
+					},
+					"content": schema.StringAttribute{
+						CustomType: jsonStringType{},
+						Computed:   true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *serviceVirtualChannelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config serviceVirtualChannelModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := d.client.GetVirtualChannel(ctx, uint(config.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Virtual-Channel",
+			fmt.Sprintf("Virtual channel service with ID %d not found (%s)", config.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	state, diags := flattenVirtualChannel(ctx, service)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
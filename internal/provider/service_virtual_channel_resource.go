@@ -0,0 +1,454 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &serviceVirtualChannelResource{}
+	_ resource.ResourceWithConfigure   = &serviceVirtualChannelResource{}
+	_ resource.ResourceWithImportState = &serviceVirtualChannelResource{}
+)
+
+// NewServiceVirtualChannelResource is a helper function to simplify the provider implementation.
+func NewServiceVirtualChannelResource() resource.Resource {
+	return &serviceVirtualChannelResource{}
+}
+
+// serviceVirtualChannelResource is the resource implementation.
+type serviceVirtualChannelResource struct {
+	client apiClient
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *serviceVirtualChannelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *serviceVirtualChannelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_virtual_channel"
+}
+
+// optionalSourceReferenceAttribute is an optional sourceReferenceAttribute.
+func optionalSourceReferenceAttribute(description string) schema.SingleNestedAttribute {
+	attribute := sourceReferenceAttribute(description)
+	attribute.Required = false
+	attribute.Optional = true
+	return attribute
+}
+
+// Schema defines the schema for the resource.
+func (r *serviceVirtualChannelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a virtual channel service, a linear channel built on a base live source and scheduled content (see https://developers.broadpeak.io/reference/virtualchannelcontroller_create_v1).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "ID of the virtual channel service.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the virtual channel service.",
+			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the service, always `virtual-channel`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the virtual channel service. This is the endpoint where the channel can be played.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "Creation date of the virtual channel service.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"update_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "Date of the last update of the virtual channel service.",
+			},
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the virtual channel service, as reported by the API. Possible values are 'enabled', 'paused', or 'bypassed'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Tags for the virtual channel service.",
+				ElementType: types.StringType,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+			},
+			"base_live": sourceReferenceAttribute("The live source played when no content is scheduled on the channel."),
+			"ad_break_insertion": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"ad_server":  optionalSourceReferenceAttribute("The ad server queried to fill the ad breaks."),
+					"gap_filler": optionalSourceReferenceAttribute("The slate played when the ads do not fill an ad break."),
+				},
+				Optional:    true,
+				Description: "Ad insertion in the ad breaks of the channel.",
+			},
+			"live_ad_preroll": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"ad_server": optionalSourceReferenceAttribute("The ad server queried for the pre-roll."),
+					"max_duration": schema.Int64Attribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(0),
+						Description: "Pre-roll maximum duration (in seconds)",
+					},
+					"offset": schema.Int64Attribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(0),
+						Description: "Pre-roll relative start time (in seconds)",
+					},
+				},
+				Optional:    true,
+				Description: "Pre-roll played when a viewer joins the channel.",
+			},
+			"enable_ad_transcoding": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether ads are transcoded to match the channel. (Default: `true`)",
+				Default:     booldefault.StaticBool(true),
+			},
+			"transcoding_profile": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						Required:    true,
+						Description: "ID of the transcoding profile.",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "Name of the transcoding profile.",
+					},
+					"internal_id": schema.StringAttribute{
+						Computed:    true,
+						Description: "Internal ID of the transcoding profile.",
+					},
+					"content": schema.StringAttribute{
+						CustomType:  jsonStringType{},
+						Computed:    true,
+						Description: "Content of the transcoding profile.",
+					},
+				},
+				Optional:    true,
+				Description: "Transcoding profile used to transcode the ads.",
+			},
+		},
+	}
+}
+
+// refID returns a reference to the source of m, or nil when m is unset.
+func (m *sourceReferenceModel) refID() *apiRef {
+	if m == nil || m.ID.IsNull() || m.ID.IsUnknown() {
+		return nil
+	}
+	return &apiRef{Id: uint(m.ID.ValueInt64())}
+}
+
+// flattenOptionalSourceReference is flattenSourceReference for references
+// that may be missing.
+func flattenOptionalSourceReference(source *apiSourceRef) *sourceReferenceModel {
+	if source == nil || source.Id == 0 {
+		return nil
+	}
+	return flattenSourceReference(*source)
+}
+
+// expand builds the API input from the Terraform plan.
+func (m serviceVirtualChannelModel) expand(ctx context.Context) (apiVirtualChannelInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	input := apiVirtualChannelInput{
+		Name:                m.Name.ValueString(),
+		Tags:                []string{},
+		BaseLive:            apiRef{Id: uint(m.BaseLive.ID.ValueInt64())},
+		EnableAdTranscoding: m.EnableAdTranscoding.ValueBool(),
+	}
+	if !m.Tags.IsNull() && !m.Tags.IsUnknown() {
+		diags.Append(m.Tags.ElementsAs(ctx, &input.Tags, false)...)
+	}
+	if m.TranscodingProfile != nil {
+		input.TranscodingProfile = &apiRef{Id: uint(m.TranscodingProfile.ID.ValueInt64())}
+	}
+	if m.AdBreakInsertion != nil {
+		input.AdBreakInsertion = &apiAdBreakInsertionInput{
+			AdServer:  m.AdBreakInsertion.AdServer.refID(),
+			GapFiller: m.AdBreakInsertion.GapFiller.refID(),
+		}
+	}
+	if m.LiveAdPreRoll != nil {
+		input.LivePreRoll = &apiLivePreRollInput{
+			AdServer:    m.LiveAdPreRoll.AdServer.refID(),
+			MaxDuration: uint(m.LiveAdPreRoll.MaxDuration.ValueInt64()),
+			Offset:      uint(m.LiveAdPreRoll.Offset.ValueInt64()),
+		}
+	}
+	return input, diags
+}
+
+// flattenVirtualChannel converts an API virtual channel service into its
+// Terraform model.
+func flattenVirtualChannel(ctx context.Context, service apiVirtualChannel) (serviceVirtualChannelModel, diag.Diagnostics) {
+	if service.Tags == nil {
+		service.Tags = []string{}
+	}
+	tags, diags := types.ListValueFrom(ctx, types.StringType, service.Tags)
+
+	state := serviceVirtualChannelModel{
+		ID:                  types.Int64Value(int64(service.Id)),
+		Name:                types.StringValue(service.Name),
+		Type:                types.StringValue(service.Type),
+		URL:                 types.StringValue(service.Url),
+		CreationDate:        types.StringValue(service.CreationDate),
+		UpdateDate:          types.StringValue(service.UpdateDate),
+		State:               types.StringValue(service.State),
+		Tags:                tags,
+		BaseLive:            flattenSourceReference(service.BaseLive),
+		EnableAdTranscoding: types.BoolValue(service.EnableAdTranscoding),
+	}
+	if service.TranscodingProfile.Id != 0 {
+		state.TranscodingProfile = &transcodingProfileDataSourceModel{
+			ID:         types.Int64Value(int64(service.TranscodingProfile.Id)),
+			Name:       types.StringValue(service.TranscodingProfile.Name),
+			InternalId: types.StringValue(service.TranscodingProfile.InternalId),
+			Content:    newJSONStringValue(service.TranscodingProfile.Content),
+		}
+	}
+	if p := service.AdBreakInsertion; p != nil {
+		state.AdBreakInsertion = &adBreakInsertionModel{
+			AdServer:  flattenOptionalSourceReference(p.AdServer),
+			GapFiller: flattenOptionalSourceReference(p.GapFiller),
+		}
+	}
+	if p := service.LivePreRoll; p != nil {
+		state.LiveAdPreRoll = &virtualChannelPreRollModel{
+			AdServer:    flattenOptionalSourceReference(p.AdServer),
+			MaxDuration: types.Int64Value(int64(p.MaxDuration)),
+			Offset:      types.Int64Value(int64(p.Offset)),
+		}
+	}
+	return state, diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *serviceVirtualChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	//--------------------------------------------------------------------.
+	// 1. Decode plan.
+	//--------------------------------------------------------------------.
+	var plan serviceVirtualChannelModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	//--------------------------------------------------------------------.
+	// 2. Convert plan -> API input.
+	//--------------------------------------------------------------------.
+	input, diags := plan.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	//--------------------------------------------------------------------.
+	// 3. Call Broadpeak API.
+	//--------------------------------------------------------------------.
+	service, err := r.client.CreateVirtualChannel(ctx, input)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error creating Virtual-Channel", "Could not create Virtual-Channel", err)
+		return
+	}
+
+	//--------------------------------------------------------------------.
+	// 4. Save state.
+	//--------------------------------------------------------------------.
+	state, diags := flattenVirtualChannel(ctx, service)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *serviceVirtualChannelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state serviceVirtualChannelModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	service, err := r.client.GetVirtualChannel(ctx, uint(state.ID.ValueInt64()))
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Virtual channel service not found, removing from state", map[string]interface{}{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Virtual-Channel",
+			fmt.Sprintf("Could not read virtual channel service ID %d: %s", state.ID.ValueInt64(), err),
+		)
+		return
+	}
+
+	state, diags = flattenVirtualChannel(ctx, service)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *serviceVirtualChannelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan serviceVirtualChannelModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input, diags := plan.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID := uint(plan.ID.ValueInt64())
+	tflog.Debug(ctx, "Update - Update Doc sent to BPKIO", map[string]interface{}{"id": serviceID, "updates": input})
+
+	service, err := r.client.UpdateVirtualChannel(ctx, serviceID, input)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error updating Virtual-Channel", fmt.Sprintf("Could not update virtual channel service ID %d", serviceID), err)
+		return
+	}
+
+	state, diags := flattenVirtualChannel(ctx, service)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *serviceVirtualChannelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state serviceVirtualChannelModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteVirtualChannel(ctx, uint(state.ID.ValueInt64()))
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Virtual-Channel",
+			"Could not delete virtual channel service, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource state from the ID.
+func (r *serviceVirtualChannelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing virtual channel service",
+			fmt.Sprintf("Invalid ID format: %s. Expected a numeric ID. Error: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// serviceVirtualChannelModel maps the schema of the virtual channel resource
+// and data source.
+type serviceVirtualChannelModel struct {
+	ID                  types.Int64                        `tfsdk:"id"`
+	Name                types.String                       `tfsdk:"name"`
+	Type                types.String                       `tfsdk:"type"`
+	URL                 types.String                       `tfsdk:"url"`
+	CreationDate        types.String                       `tfsdk:"creation_date"`
+	UpdateDate          types.String                       `tfsdk:"update_date"`
+	State               types.String                       `tfsdk:"state"`
+	Tags                types.List                         `tfsdk:"tags"`
+	BaseLive            *sourceReferenceModel              `tfsdk:"base_live"`
+	AdBreakInsertion    *adBreakInsertionModel             `tfsdk:"ad_break_insertion"`
+	LiveAdPreRoll       *virtualChannelPreRollModel        `tfsdk:"live_ad_preroll"`
+	EnableAdTranscoding types.Bool                         `tfsdk:"enable_ad_transcoding"`
+	TranscodingProfile  *transcodingProfileDataSourceModel `tfsdk:"transcoding_profile"`
+}
+
+type adBreakInsertionModel struct {
+	AdServer  *sourceReferenceModel `tfsdk:"ad_server"`
+	GapFiller *sourceReferenceModel `tfsdk:"gap_filler"`
+}
+
+type virtualChannelPreRollModel struct {
+	AdServer    *sourceReferenceModel `tfsdk:"ad_server"`
+	MaxDuration types.Int64           `tfsdk:"max_duration"`
+	Offset      types.Int64           `tfsdk:"offset"`
+}
@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServiceVirtualChannel_Basic(t *testing.T) {
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_service_virtual_channel.test"
+	name := "tf-acc-test-vc-" + randomSuffix()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVirtualChannelConfig(apiKey, name, `["tf-acc"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "type", "virtual-channel"),
+					resource.TestCheckResourceAttr(resourceName, "state", "enabled"),
+					resource.TestCheckResourceAttr(resourceName, "enable_ad_transcoding", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "base_live.id", "bpkio_source_live.live", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "ad_break_insertion.ad_server.id", "bpkio_source_adserver.adserver", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "ad_break_insertion.gap_filler.id", "bpkio_source_slate.slate", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "url"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccServiceVirtualChannelConfig(apiKey, name, tags string) string {
+	return fmt.Sprintf(`
+provider "bpkio" {
+  api_key = "%[1]s"
+}
+
+resource "bpkio_source_live" "live" {
+  name = "%[2]s-live"
+  url  = "%[4]s"
+}
+
+resource "bpkio_source_slate" "slate" {
+  name = "%[2]s-slate"
+  url  = "%[5]s"
+}
+
+resource "bpkio_source_adserver" "adserver" {
+  name = "%[2]s-adserver"
+  url  = "%[6]s"
+}
+
+resource "bpkio_service_virtual_channel" "test" {
+  name = "%[2]s"
+  tags = %[3]s
+
+  base_live = {
+    id = bpkio_source_live.live.id
+  }
+
+  ad_break_insertion = {
+    ad_server = {
+      id = bpkio_source_adserver.adserver.id
+    }
+    gap_filler = {
+      id = bpkio_source_slate.slate.id
+    }
+  }
+}
+
+data "bpkio_service_virtual_channel" "test" {
+  id = bpkio_service_virtual_channel.test.id
+}
+`, apiKey, name, tags, LiveURL, SlateURL, AdServerURL)
+}
+
+func TestServiceVirtualChannelResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	profile := client.addTranscodingProfile("profile", `{"packaging":{}}`)
+	resourceName := "bpkio_service_virtual_channel.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVirtualChannelConfig("fake", "vc", `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "type", "virtual-channel"),
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "base_live.type", "live"),
+					resource.TestCheckResourceAttr(resourceName, "ad_break_insertion.gap_filler.type", "slate"),
+					resource.TestCheckNoResourceAttr(resourceName, "live_ad_preroll"),
+					resource.TestCheckNoResourceAttr(resourceName, "transcoding_profile"),
+					resource.TestCheckResourceAttrPair("data.bpkio_service_virtual_channel.test", "url", resourceName, "url"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "bpkio_source_live" "live" {
+  name = "vc-live"
+  url  = %[1]q
+}
+
+resource "bpkio_source_adserver" "adserver" {
+  name = "vc-adserver"
+  url  = %[2]q
+}
+
+resource "bpkio_service_virtual_channel" "test" {
+  name                  = "vc"
+  enable_ad_transcoding = false
+
+  base_live = {
+    id = bpkio_source_live.live.id
+  }
+
+  live_ad_preroll = {
+    ad_server = {
+      id = bpkio_source_adserver.adserver.id
+    }
+    max_duration = 30
+  }
+
+  transcoding_profile = {
+    id = %[3]d
+  }
+}
+`, LiveURL, AdServerURL, profile.Id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enable_ad_transcoding", "false"),
+					resource.TestCheckNoResourceAttr(resourceName, "ad_break_insertion"),
+					resource.TestCheckResourceAttr(resourceName, "live_ad_preroll.max_duration", "30"),
+					resource.TestCheckResourceAttr(resourceName, "live_ad_preroll.offset", "0"),
+					resource.TestCheckResourceAttr(resourceName, "live_ad_preroll.ad_server.type", "ad-server"),
+					resource.TestCheckResourceAttr(resourceName, "transcoding_profile.name", "profile"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `
+resource "bpkio_service_virtual_channel" "test" {
+  name      = "vc"
+  base_live = { id = 999 }
+}
+`,
+				ExpectError: regexp.MustCompile(`baseLive must reference an existing live source`),
+			},
+		},
+	})
+}
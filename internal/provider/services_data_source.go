@@ -24,7 +24,7 @@ var (
 
 // servicesDataSource is the data source implementation.
 type servicesDataSource struct {
-	client *broadpeakio.BroadpeakClient
+	client apiClient
 }
 
 // NewServicesDataSource is a helper function to simplify the provider implementation.
@@ -40,11 +40,11 @@ func (d *servicesDataSource) Configure(_ context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -74,6 +74,7 @@ func (d *servicesDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 					stringvalidator.OneOf("enabled", "disabled"),
 				},
 			},
+			"max_results": maxResultsAttribute("services"),
 			"services": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -117,7 +118,10 @@ func (d *servicesDataSource) Read(ctx context.Context, req datasource.ReadReques
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
-	services, err := d.client.GetAllServices(0, 2000)
+	services, truncated, err := listAll(d.client.GetAllServices, state.MaxResults.ValueInt64(), func(service broadpeakio.ServiceOutput) bool {
+		return (state.Type.IsNull() || service.Type == state.Type.ValueString()) &&
+			(state.State.IsNull() || service.State == state.State.ValueString())
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -147,11 +151,10 @@ func (d *servicesDataSource) Read(ctx context.Context, req datasource.ReadReques
 			Tags:         tagsList,
 		}
 
-		// Filter by type
-		if (state.Type.IsNull() || (service.Type == state.Type.ValueString() && !state.Type.IsNull())) &&
-			(state.State.IsNull() || (service.State == state.State.ValueString() && !state.State.IsNull())) {
-			state.Services = append(state.Services, serviceState)
-		}
+		state.Services = append(state.Services, serviceState)
+	}
+	if truncated {
+		addTruncationWarning(&resp.Diagnostics, state.MaxResults, "services")
 	}
 
 	// Set state
@@ -181,9 +184,10 @@ func flattenService(s broadpeakio.ServiceOutput, ctx context.Context) (serviceDa
 
 // servicesDataSourceModel maps the data source schema data.
 type servicesDataSourceModel struct {
-	Type     types.String             `tfsdk:"type"`
-	State    types.String             `tfsdk:"state"`
-	Services []serviceDataSourceModel `tfsdk:"services"`
+	Type       types.String             `tfsdk:"type"`
+	State      types.String             `tfsdk:"state"`
+	MaxResults types.Int64              `tfsdk:"max_results"`
+	Services   []serviceDataSourceModel `tfsdk:"services"`
 }
 
 // serviceModel maps service schema data.
@@ -24,7 +24,7 @@ var (
 
 // sourceAdServerDataSource is the data source implementation.
 type sourceAdServerDataSource struct {
-	client *broadpeakio.BroadpeakClient
+	client apiClient
 }
 
 // NewSourceAdServerDataSource is a helper function to simplify the provider implementation.
@@ -40,11 +40,11 @@ func (d *sourceAdServerDataSource) Configure(_ context.Context, req datasource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -28,6 +29,7 @@ var (
 	_ resource.Resource                = &sourceAdServerResource{}
 	_ resource.ResourceWithConfigure   = &sourceAdServerResource{}
 	_ resource.ResourceWithImportState = &sourceAdServerResource{}
+	_ resource.ResourceWithModifyPlan  = &sourceAdServerResource{}
 )
 
 // NewSourceAdServerResource is a helper function to simplify the provider implementation.
@@ -37,7 +39,16 @@ func NewSourceAdServerResource() resource.Resource {
 
 // sourceAdServerResource is the resource implementation.
 type sourceAdServerResource struct {
-	client *broadpeakio.BroadpeakClient
+	client             apiClient
+	deletionProtection bool
+}
+
+// sourceAdServerResourceModel maps the resource schema data: the data source
+// model and the settings that only exist on the resource.
+type sourceAdServerResourceModel struct {
+	sourceAdServerDataSourceModel
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	WaitForDetach      types.String `tfsdk:"wait_for_detach"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -47,17 +58,18 @@ func (r *sourceAdServerResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
 	r.client = client
+	r.deletionProtection = providerDeletionProtection(req.ProviderData)
 }
 
 // Metadata returns the resource type name.
@@ -128,10 +140,17 @@ func (r *sourceAdServerResource) Schema(_ context.Context, _ resource.SchemaRequ
 					},
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("ad server"),
+			"wait_for_detach":     waitForDetachAttribute("ad server"),
 		},
 	}
 }
 
+// ModifyPlan plans the provider default of deletion_protection.
+func (r *sourceAdServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, req, resp, r.deletionProtection)
+}
+
 func (r *sourceAdServerResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
@@ -140,7 +159,7 @@ func (r *sourceAdServerResource) Create(
 	//--------------------------------------------------------------------
 	// 1. Decode the plan into a strongly-typed model
 	//--------------------------------------------------------------------
-	var plan sourceAdServerDataSourceModel
+	var plan sourceAdServerResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -180,10 +199,7 @@ func (r *sourceAdServerResource) Create(
 	//--------------------------------------------------------------------
 	created, err := r.client.CreateAdServer(adInput)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Ad-Server",
-			fmt.Sprintf("Could not create Ad-Server: %s", err),
-		)
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error Creating Ad-Server", "Could not create Ad-Server", err)
 		return
 	}
 
@@ -236,7 +252,11 @@ func (r *sourceAdServerResource) Create(
 	//--------------------------------------------------------------------
 	// 5. Save state
 	//--------------------------------------------------------------------
-	diags = resp.State.Set(ctx, newState)
+	diags = resp.State.Set(ctx, sourceAdServerResourceModel{
+		sourceAdServerDataSourceModel: newState,
+		DeletionProtection:            plan.DeletionProtection,
+		WaitForDetach:                 plan.WaitForDetach,
+	})
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -249,7 +269,7 @@ func (r *sourceAdServerResource) Read(
 	//--------------------------------------------------------------------
 	// 1. Load the prior state (contains the ID)
 	//--------------------------------------------------------------------
-	var state sourceAdServerDataSourceModel
+	var state sourceAdServerResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -260,6 +280,11 @@ func (r *sourceAdServerResource) Read(
 	// 2. Query Broadpeak for the latest object
 	//--------------------------------------------------------------------
 	src, err := r.client.GetAdServer(uint(state.ID.ValueInt64()))
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Source ad-server not found, removing from state", map[string]interface{}{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Source Ad-Server",
@@ -319,7 +344,11 @@ func (r *sourceAdServerResource) Read(
 	//--------------------------------------------------------------------
 	// 5. Save state
 	//--------------------------------------------------------------------
-	diags = resp.State.Set(ctx, newState)
+	diags = resp.State.Set(ctx, sourceAdServerResourceModel{
+		sourceAdServerDataSourceModel: newState,
+		DeletionProtection:            refreshedDeletionProtection(state.DeletionProtection, r.deletionProtection),
+		WaitForDetach:                 state.WaitForDetach,
+	})
 	resp.Diagnostics.Append(diags...)
 }
 
@@ -331,7 +360,7 @@ func (r *sourceAdServerResource) Update(
 	//--------------------------------------------------------------------
 	// 1. Decode the planned values
 	//--------------------------------------------------------------------
-	var plan sourceAdServerDataSourceModel
+	var plan sourceAdServerResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -371,10 +400,7 @@ func (r *sourceAdServerResource) Update(
 	//--------------------------------------------------------------------
 	adID := uint(plan.ID.ValueInt64())
 	if _, err := r.client.UpdateAdServer(adID, updInput); err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Ad-Server",
-			fmt.Sprintf("Could not update ad-server ID %d: %s", adID, err),
-		)
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error Updating Ad-Server", fmt.Sprintf("Could not update ad-server ID %d", adID), err)
 		return
 	}
 
@@ -438,22 +464,38 @@ func (r *sourceAdServerResource) Update(
 		QueryParameters: paramsList,
 	}
 
-	diags = resp.State.Set(ctx, newState)
+	diags = resp.State.Set(ctx, sourceAdServerResourceModel{
+		sourceAdServerDataSourceModel: newState,
+		DeletionProtection:            plan.DeletionProtection,
+		WaitForDetach:                 plan.WaitForDetach,
+	})
 	resp.Diagnostics.Append(diags...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *sourceAdServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
-	var state sourceAdServerDataSourceModel
+	var state sourceAdServerResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if !checkDeletionProtection(ctx, req.State, &resp.Diagnostics, "ad server", state.ID.ValueInt64()) {
+		return
+	}
+
 	// Delete existing adserver
-	_, err := r.client.DeleteAdServer(uint(state.ID.ValueInt64()))
+	id := uint(state.ID.ValueInt64())
+	err := deleteWhenDetached(ctx, state.WaitForDetach, func() error {
+		_, err := r.client.DeleteAdServer(id)
+		return err
+	})
+	if isConflict(err) {
+		addSourceInUseError(ctx, &resp.Diagnostics, r.client, "Error Deleting Source AdServer", "ad server", id, err)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Source AdServer",
@@ -19,9 +19,6 @@ var (
 
 func TestAccSourceAdServer_Basic(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	name := "tf-acc-test-adserver-" + randomSuffix()
 	resourceName := "bpkio_source_adserver.test"
@@ -79,9 +76,6 @@ resource "bpkio_source_adserver" "test" {
 
 func TestAccSourceAdServer_ComputedFields(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-test-adserver-" + randomSuffix()
 	resourceName := "bpkio_source_adserver.test"
 	resource.Test(t, resource.TestCase{
@@ -101,9 +95,6 @@ func TestAccSourceAdServer_ComputedFields(t *testing.T) {
 
 func TestAccSourceAdServer_MinimalConfig(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-test-adserver-" + randomSuffix()
 	resourceName := "bpkio_source_adserver.test"
 	resource.Test(t, resource.TestCase{
@@ -123,9 +114,6 @@ func TestAccSourceAdServer_MinimalConfig(t *testing.T) {
 
 func TestAccSourceAdServer_MissingName(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	config := fmt.Sprintf(`
 provider "bpkio" {
   api_key = "%s"
@@ -149,9 +137,6 @@ resource "bpkio_source_adserver" "test" {
 
 func TestAccSourceAdServer_LongSpecialName(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	longName := strings.Repeat("x", 101) // >100 chars to trigger validation error
 	config := fmt.Sprintf(`
 provider "bpkio" {
@@ -177,9 +162,6 @@ resource "bpkio_source_adserver" "test" {
 
 func TestAccSourceAdServer_DuplicateNameURL(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	unique := "tf-acc-dupe-" + randomSuffix()
 	dupeConfig := fmt.Sprintf(`
 provider "bpkio" {
@@ -208,9 +190,6 @@ resource "bpkio_source_adserver" "second" {
 
 func TestAccSourceAdServer_QueryParameters(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-test-adserver-" + randomSuffix()
 	resourceName := "bpkio_source_adserver.test"
 	config := fmt.Sprintf(`
@@ -250,9 +229,6 @@ resource "bpkio_source_adserver" "test" {
 
 func TestAccSourceAdServer_InvalidURL(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-test-adserver-" + randomSuffix()
 	badURL := "https://this-url-will-not-exist.example.com"
 	config := fmt.Sprintf(`
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &sourceAssetCatalogDataSource{}
+	_ datasource.DataSourceWithConfigure = &sourceAssetCatalogDataSource{}
+)
+
+// sourceAssetCatalogDataSource is the data source implementation.
+type sourceAssetCatalogDataSource struct {
+	client apiClient
+}
+
+// NewSourceAssetCatalogDataSource is a helper function to simplify the provider implementation.
+func NewSourceAssetCatalogDataSource() datasource.DataSource {
+	return &sourceAssetCatalogDataSource{}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *sourceAssetCatalogDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *sourceAssetCatalogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_asset_catalog"
+}
+
+// Schema defines the schema for the data source.
+func (d *sourceAssetCatalogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads an asset catalog source.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the source asset catalog.",
+			},
+			"name": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				Computed: true,
+			},
+			"url": schema.StringAttribute{
+				Computed: true,
+			},
+			"asset_sample": schema.StringAttribute{
+				Computed: true,
+			},
+			"description": schema.StringAttribute{
+				Computed: true,
+			},
+			"origin": originDataSourceAttribute(false),
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *sourceAssetCatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config sourceAssetCatalogModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the source from the API
+	source, err := d.client.GetAssetCatalog(ctx, uint(config.ID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Source Asset Catalog",
+			fmt.Sprintf("Source asset catalog with ID %d not found (%s)", config.ID.ValueInt64(), err.Error()),
+		)
+		return
+	}
+
+	var state sourceAssetCatalogModel
+	diags := state.flatten(source)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// sourceAssetCatalogModel maps the schema of the source asset catalog
+// resource and data source.
+type sourceAssetCatalogModel struct {
+	restSourceModel
+	AssetSample types.String `tfsdk:"asset_sample"`
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewSourceAssetCatalogResource is a helper function to simplify the provider implementation.
+func NewSourceAssetCatalogResource() resource.Resource {
+	return &restSourceResource{
+		typeName:    "source_asset_catalog",
+		title:       "Source Asset Catalog",
+		description: "Manages an asset catalog source: a folder of VOD assets served from a common base URL.",
+		url:         "The base URL of the assets of the catalog.",
+		attributes: map[string]schema.Attribute{
+			"asset_sample": schema.StringAttribute{
+				Required:    true,
+				Description: "The path, relative to `url`, of an asset of the catalog. Broadpeak fetches it to validate the catalog.",
+			},
+		},
+		detachObject: "asset catalog",
+		newModel:     func() restSourceResourceModel { return &sourceAssetCatalogResourceModel{} },
+		api: restSourceMethods{
+			create: apiClient.CreateAssetCatalog,
+			get:    apiClient.GetAssetCatalog,
+			update: apiClient.UpdateAssetCatalog,
+			delete: apiClient.DeleteAssetCatalog,
+		},
+	}
+}
+
+// sourceAssetCatalogResourceModel maps the resource schema data: the data
+// source model and the settings that only exist on the resource.
+type sourceAssetCatalogResourceModel struct {
+	sourceAssetCatalogModel
+	WaitForDetach types.String `tfsdk:"wait_for_detach"`
+}
+
+func (m sourceAssetCatalogModel) expand(ctx context.Context) (apiSource, diag.Diagnostics) {
+	input, diags := m.restSourceModel.expand(ctx)
+	input.AssetSample = m.AssetSample.ValueString()
+	return input, diags
+}
+
+func (m *sourceAssetCatalogModel) flatten(source apiSource) diag.Diagnostics {
+	diags := m.restSourceModel.flatten(source)
+	m.AssetSample = types.StringValue(source.AssetSample)
+	return diags
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccSourceAssetCatalog_Basic(t *testing.T) {
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_source_asset_catalog.test"
+	name := "tf-acc-test-catalog-" + randomSuffix()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceAssetCatalogConfig(apiKey, name, "tearsofsteel/manifest.m3u8"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "type", "asset-catalog"),
+					resource.TestCheckResourceAttr(resourceName, "asset_sample", "tearsofsteel/manifest.m3u8"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrPair("data.bpkio_source_asset_catalog.test", "url", resourceName, "url"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSourceAssetCatalogConfig(apiKey, name, sample string) string {
+	return fmt.Sprintf(`
+provider "bpkio" {
+  api_key = "%s"
+}
+
+resource "bpkio_source_asset_catalog" "test" {
+  name         = "%s"
+  url          = "https://origin.broadpeak.io/bpk-vod/voddemo/default/5min/"
+  asset_sample = "%s"
+}
+
+data "bpkio_source_asset_catalog" "test" {
+  id = bpkio_source_asset_catalog.test.id
+}
+
+data "bpkio_sources" "catalogs" {
+  type = "asset-catalog"
+
+  depends_on = [bpkio_source_asset_catalog.test]
+}
+`, apiKey, name, sample)
+}
+
+func TestSourceAssetCatalogModel_AssetSample(t *testing.T) {
+	ctx := context.Background()
+
+	var m sourceAssetCatalogModel
+	require.False(t, m.flatten(apiSource{Id: 1, Type: "asset-catalog", Url: "https://origin.example.com/vod/", AssetSample: "movie/index.m3u8"}).HasError())
+	require.Equal(t, types.StringValue("movie/index.m3u8"), m.AssetSample)
+	require.Equal(t, types.StringValue("https://origin.example.com/vod/"), m.URL)
+
+	input, diags := m.expand(ctx)
+	require.False(t, diags.HasError())
+	require.Equal(t, "movie/index.m3u8", input.AssetSample)
+}
+
+func TestSourceAssetCatalogResource_AssetSample(t *testing.T) {
+	testUnitPreCheck(t)
+
+	resourceName := "bpkio_source_asset_catalog.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceAssetCatalogConfig("fake", "catalog", "movie/index.m3u8"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "asset_sample", "movie/index.m3u8"),
+					resource.TestCheckResourceAttr("data.bpkio_source_asset_catalog.test", "asset_sample", "movie/index.m3u8"),
+				),
+			},
+			{
+				Config: testAccSourceAssetCatalogConfig("fake", "catalog", "series/index.m3u8"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "asset_sample", "series/index.m3u8"),
+			},
+			{
+				Config:      testAccSourceAssetCatalogConfig("fake", "catalog", ""),
+				ExpectError: regexp.MustCompile(`assetSample should not be empty`),
+			},
+		},
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewSourceAssetResource is a helper function to simplify the provider implementation.
+func NewSourceAssetResource() resource.Resource {
+	return &restSourceResource{
+		typeName:    "source_asset",
+		title:       "Source Asset",
+		description: "Manages a VOD asset source.",
+		url:         "The URL of the VOD asset.",
+		attributes: map[string]schema.Attribute{
+			"format": schema.StringAttribute{
+				Computed:    true,
+				Description: "The format of the source asset, as detected by the API from the URL.",
+			},
+		},
+		detachObject: "asset",
+		newModel:     func() restSourceResourceModel { return &sourceAssetResourceModel{} },
+		api: restSourceMethods{
+			create: apiClient.CreateAsset,
+			get:    apiClient.GetAsset,
+			update: apiClient.UpdateAsset,
+			delete: apiClient.DeleteAsset,
+		},
+	}
+}
+
+// sourceAssetResourceModel maps the resource schema data: the data source
+// model and the settings that only exist on the resource.
+type sourceAssetResourceModel struct {
+	sourceAssetModel
+	WaitForDetach types.String `tfsdk:"wait_for_detach"`
+}
+
+func (m *sourceAssetModel) flatten(source apiSource) diag.Diagnostics {
+	diags := m.restSourceModel.flatten(source)
+	m.Format = types.StringValue(source.Format)
+	return diags
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSourceAsset_Basic(t *testing.T) {
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_source_asset.test"
+	name := "tf-acc-test-asset-" + randomSuffix()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceAssetConfig(apiKey, name, "Origin-Token"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "type", "asset"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "format"),
+					resource.TestCheckResourceAttr(resourceName, "origin.custom_headers.0.name", "Origin-Token"),
+					resource.TestCheckResourceAttrPair("data.bpkio_source_asset.test", "url", resourceName, "url"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSourceAssetConfig(apiKey, name, header string) string {
+	return fmt.Sprintf(`
+provider "bpkio" {
+  api_key = "%s"
+}
+
+resource "bpkio_source_asset" "test" {
+  name        = "%s"
+  description = "VOD asset"
+  url         = "https://origin.broadpeak.io/bpk-vod/voddemo/default/5min/tearsofsteel/manifest.m3u8"
+
+  origin = {
+    custom_headers = [
+      {
+        name  = "%s"
+        value = "secret"
+      },
+    ]
+  }
+}
+
+data "bpkio_source_asset" "test" {
+  id = bpkio_source_asset.test.id
+}
+`, apiKey, name, header)
+}
+
+func TestSourceAssetResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	resourceName := "bpkio_source_asset.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceAssetConfig("fake", "asset-initial", "X-First"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "asset-initial"),
+					resource.TestCheckResourceAttr(resourceName, "type", "asset"),
+					resource.TestCheckResourceAttr(resourceName, "format", "HLS"),
+					resource.TestCheckResourceAttr(resourceName, "origin.custom_headers.0.name", "X-First"),
+					resource.TestCheckResourceAttr("data.bpkio_source_asset.test", "name", "asset-initial"),
+				),
+			},
+			{
+				Config: testAccSourceAssetConfig("fake", "asset-updated", "X-Second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "asset-updated"),
+					resource.TestCheckResourceAttr(resourceName, "origin.custom_headers.0.name", "X-Second"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// The fake gave ID 1 to the only source of the test.
+				PreConfig: func() {
+					if err := client.DeleteAsset(context.Background(), 1); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSourceAssetConfig("fake", "asset-updated", "X-Second"),
+				Check:  resource.TestCheckResourceAttrSet(resourceName, "id"),
+			},
+		},
+	})
+}
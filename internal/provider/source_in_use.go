@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// detachPollInterval is the time between two attempts to delete a source
+// that services still use.
+var detachPollInterval = 5 * time.Second
+
+// waitForDetachAttribute is the wait_for_detach attribute of a resource
+// managing the given kind of source.
+func waitForDetachAttribute(object string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Description: fmt.Sprintf("How long to keep retrying the deletion of the %s while services still use it, as a duration such as `2m`. "+
+			"Useful when another resource of the same apply stops using it. The deletion fails at once when unset.", object),
+		Validators: []validator.String{
+			durationValidator{},
+		},
+	}
+}
+
+// deleteWhenDetached calls del until it succeeds, fails with anything but a
+// conflict, or the wait_for_detach timeout elapses.
+func deleteWhenDetached(ctx context.Context, waitForDetach types.String, del func() error) error {
+	// The value is validated at plan time, and unset means no wait.
+	timeout, _ := time.ParseDuration(waitForDetach.ValueString())
+	deadline := time.Now().Add(timeout)
+
+	for {
+		err := del()
+		if !isConflict(err) || !time.Now().Before(deadline) {
+			return err
+		}
+		tflog.Debug(ctx, "Source still in use, retrying its deletion", map[string]interface{}{
+			"error":     err.Error(),
+			"remaining": time.Until(deadline).Round(time.Second).String(),
+		})
+
+		timer := time.NewTimer(min(detachPollInterval, time.Until(deadline)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// serviceSourceIDs returns the IDs of the sources and ad servers referenced
+// by a service. Services of unknown types reference none.
+func serviceSourceIDs(ctx context.Context, client apiClient, service broadpeakio.ServiceOutput) ([]uint, error) {
+	switch service.Type {
+	case "ad-insertion":
+		s, err := client.GetAdInsertion(ctx, service.Id)
+		return []uint{
+			s.Source.Id,
+			s.LiveAdReplacement.AdServer.Id,
+			s.LiveAdReplacement.GapFiller.Id,
+			s.LiveAdPreRoll.AdServer.Id,
+			s.VodAdInsertion.AdServer.Id,
+		}, err
+	case "content-replacement":
+		s, err := client.GetContentReplacement(ctx, service.Id)
+		return []uint{s.Source.Id, s.Replacement.Id}, err
+	case "virtual-channel":
+		s, err := client.GetVirtualChannel(ctx, service.Id)
+		ids := []uint{s.BaseLive.Id}
+		var refs []*apiSourceRef
+		if s.AdBreakInsertion != nil {
+			refs = append(refs, s.AdBreakInsertion.AdServer, s.AdBreakInsertion.GapFiller)
+		}
+		if s.LivePreRoll != nil {
+			refs = append(refs, s.LivePreRoll.AdServer)
+		}
+		for _, ref := range refs {
+			if ref != nil {
+				ids = append(ids, ref.Id)
+			}
+		}
+		return ids, err
+	}
+	return nil, nil
+}
+
+// sourceDependents returns the services that reference the source with the
+// given ID. The services list does not include references, so each service
+// is read.
+func sourceDependents(ctx context.Context, client apiClient, id uint) ([]broadpeakio.ServiceOutput, error) {
+	services, _, err := listAll(client.GetAllServices, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []broadpeakio.ServiceOutput
+	for _, service := range services {
+		ids, err := serviceSourceIDs(ctx, client, service)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(ids, id) {
+			dependents = append(dependents, service)
+		}
+	}
+	return dependents, nil
+}
+
+// addSourceInUseError reports that the API refused to delete a source because
+// it is in use, naming the services that reference it.
+func addSourceInUseError(ctx context.Context, diags *diag.Diagnostics, client apiClient, summary, object string, id uint, err error) {
+	dependents, lookupErr := sourceDependents(ctx, client, id)
+	if lookupErr != nil {
+		tflog.Debug(ctx, "Unable to look up the services using a source", map[string]interface{}{"id": id, "error": lookupErr.Error()})
+		diags.AddError(summary, fmt.Sprintf("The %s with ID %d is still in use and could not be deleted: %s", object, id, err))
+		return
+	}
+	if len(dependents) == 0 {
+		diags.AddError(summary, fmt.Sprintf("The %s with ID %d is still in use and could not be deleted, but no service references it. "+
+			"It may still be scheduled in a slot. API error: %s", object, id, err))
+		return
+	}
+
+	lines := make([]string, 0, len(dependents))
+	for _, service := range dependents {
+		lines = append(lines, fmt.Sprintf("  - %s service %d %q", service.Type, service.Id, service.Name))
+	}
+	diags.AddError(summary, fmt.Sprintf("The %s with ID %d is still used by %d service(s):\n%s\n"+
+		"Remove it from these services before deleting it. When another resource of the same apply does so, set wait_for_detach to retry meanwhile.",
+		object, id, len(dependents), strings.Join(lines, "\n")))
+}
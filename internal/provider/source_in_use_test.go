@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	broadpeakio "github.com/bashou/bpkio-go-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeClientWithGapFiller returns a fake tenant where a slate is the gap
+// filler of an ad insertion service and the replacement of a content
+// replacement service, next to a service that does not use it.
+func newFakeClientWithGapFiller(t *testing.T) (*fakeClient, broadpeakio.Source, apiAdInsertion) {
+	t.Helper()
+	ctx := context.Background()
+	client := newFakeClient()
+	live, err := client.CreateLive(broadpeakio.LiveInput{Name: "live", Url: LiveURL})
+	require.NoError(t, err)
+	slate, err := client.CreateSlate(broadpeakio.SlateInput{Name: "slate", Url: SlateURL})
+	require.NoError(t, err)
+	adServer, err := client.CreateAdServer(broadpeakio.AdServerInput{Name: "adserver", Url: AdServerURL})
+	require.NoError(t, err)
+
+	service, err := client.CreateAdInsertion(ctx, apiAdInsertionInput{CreateAdInsertionInput: broadpeakio.CreateAdInsertionInput{
+		Name:   "with gap filler",
+		Source: &broadpeakio.Identifiable{Id: live.Id},
+		LiveAdReplacement: &broadpeakio.LiveAdReplacement{
+			AdServer:  &broadpeakio.Identifiable{Id: adServer.Id},
+			GapFiller: &broadpeakio.Identifiable{Id: slate.Id},
+		},
+	}})
+	require.NoError(t, err)
+	_, err = client.CreateAdInsertion(ctx, apiAdInsertionInput{CreateAdInsertionInput: broadpeakio.CreateAdInsertionInput{
+		Name:   "without gap filler",
+		Source: &broadpeakio.Identifiable{Id: live.Id},
+	}})
+	require.NoError(t, err)
+	_, err = client.CreateContentReplacement(ctx, apiContentReplacementInput{
+		Name:        "replaced by slate",
+		Source:      apiRef{Id: live.Id},
+		Replacement: apiRef{Id: slate.Id},
+	})
+	require.NoError(t, err)
+	return client, slate, service
+}
+
+func TestSourceDependents(t *testing.T) {
+	ctx := context.Background()
+	client, slate, _ := newFakeClientWithGapFiller(t)
+
+	dependents, err := sourceDependents(ctx, client, slate.Id)
+	require.NoError(t, err)
+	var names []string
+	for _, service := range dependents {
+		names = append(names, service.Name)
+	}
+	require.ElementsMatch(t, []string{"with gap filler", "replaced by slate"}, names)
+
+	dependents, err = sourceDependents(ctx, client, slate.Id+100)
+	require.NoError(t, err)
+	require.Empty(t, dependents)
+}
+
+func TestDeleteWhenDetached(t *testing.T) {
+	ctx := context.Background()
+	interval := detachPollInterval
+	detachPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { detachPollInterval = interval })
+
+	conflicts := func(n int) (func() error, *int) {
+		calls := 0
+		return func() error {
+			calls++
+			if calls <= n {
+				return fakeConflict("slate", 1)
+			}
+			return nil
+		}, &calls
+	}
+
+	del, calls := conflicts(2)
+	require.NoError(t, deleteWhenDetached(ctx, types.StringValue("1s"), del))
+	require.Equal(t, 3, *calls)
+
+	del, calls = conflicts(2)
+	require.True(t, isConflict(deleteWhenDetached(ctx, types.StringNull(), del)), "no wait when unset")
+	require.Equal(t, 1, *calls)
+
+	del, _ = conflicts(100)
+	require.True(t, isConflict(deleteWhenDetached(ctx, types.StringValue("50ms"), del)), "gives up after the timeout")
+
+	failure := errors.New("error: 500, body: conflict with a concurrent update")
+	attempts := 0
+	require.Equal(t, failure, deleteWhenDetached(ctx, types.StringValue("1s"), func() error {
+		attempts++
+		return failure
+	}))
+	require.Equal(t, 1, attempts, "only conflicts are retried")
+}
+
+func TestSourceSlateResource_DeleteInUse(t *testing.T) {
+	ctx := context.Background()
+	client, slate, service := newFakeClientWithGapFiller(t)
+	r := &sourceSlateResource{client: client}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	deleteSlate := func(waitForDetach types.String) diag.Diagnostics {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, sourceSlateResourceModel{
+			sourceSlateDataSourceModel: sourceSlateDataSourceModel{ID: types.Int64Value(int64(slate.Id))},
+			DeletionProtection:         types.BoolValue(false),
+			WaitForDetach:              waitForDetach,
+		}).HasError())
+
+		var resp fwresource.DeleteResponse
+		r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+		return resp.Diagnostics
+	}
+
+	diags := deleteSlate(types.StringNull())
+	require.Len(t, diags, 1)
+	require.Equal(t, "Error Deleting Source Slate", diags[0].Summary())
+	require.Contains(t, diags[0].Detail(), "is still used by 2 service(s)")
+	require.Contains(t, diags[0].Detail(), fmt.Sprintf(`ad-insertion service %d "with gap filler"`, service.Id))
+	require.Contains(t, diags[0].Detail(), `"replaced by slate"`)
+	require.NotContains(t, diags[0].Detail(), "without gap filler")
+
+	// Both services stop using the slate while the deletion waits.
+	interval := detachPollInterval
+	detachPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { detachPollInterval = interval })
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		delete(client.adInsertions, service.Id)
+		clear(client.contentReplacements)
+	}()
+	require.False(t, deleteSlate(types.StringValue("5s")).HasError())
+	_, err := client.GetSlate(slate.Id)
+	require.True(t, isNotFound(err))
+}
@@ -7,8 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	broadpeakio "github.com/bashou/bpkio-go-sdk"
-
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -25,7 +23,7 @@ var (
 
 // sourceLiveDataSource is the data source implementation.
 type sourceLiveDataSource struct {
-	client *broadpeakio.BroadpeakClient
+	client apiClient
 }
 
 // NewSourceLiveDataSource is a helper function to simplify the provider implementation.
@@ -41,11 +39,11 @@ func (d *sourceLiveDataSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -29,6 +30,7 @@ var (
 	_ resource.Resource                = &sourceLiveResource{}
 	_ resource.ResourceWithConfigure   = &sourceLiveResource{}
 	_ resource.ResourceWithImportState = &sourceLiveResource{}
+	_ resource.ResourceWithModifyPlan  = &sourceLiveResource{}
 )
 
 // NewSourceLiveResource is a helper function to simplify the provider implementation.
@@ -38,7 +40,16 @@ func NewSourceLiveResource() resource.Resource {
 
 // sourceLiveResource is the resource implementation.
 type sourceLiveResource struct {
-	client *broadpeakio.BroadpeakClient
+	client             apiClient
+	deletionProtection bool
+}
+
+// sourceLiveResourceModel maps the resource schema data: the data source
+// model and the settings that only exist on the resource.
+type sourceLiveResourceModel struct {
+	sourceLiveDataSourceModel
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	WaitForDetach      types.String `tfsdk:"wait_for_detach"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -48,17 +59,18 @@ func (r *sourceLiveResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
 	r.client = client
+	r.deletionProtection = providerDeletionProtection(req.ProviderData)
 }
 
 // Metadata returns the resource type name.
@@ -136,14 +148,21 @@ func (r *sourceLiveResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Description: "The origin configuration for the source live.",
 			},
+			"deletion_protection": deletionProtectionAttribute("live source"),
+			"wait_for_detach":     waitForDetachAttribute("live source"),
 		},
 	}
 }
 
+// ModifyPlan plans the provider default of deletion_protection.
+func (r *sourceLiveResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, req, resp, r.deletionProtection)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *sourceLiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve the plan into a strongly typed model
-	var plan sourceLiveDataSourceModel
+	var plan sourceLiveResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -247,13 +266,17 @@ func (r *sourceLiveResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Save the state
-	diags = resp.State.Set(ctx, result)
+	diags = resp.State.Set(ctx, sourceLiveResourceModel{
+		sourceLiveDataSourceModel: result,
+		DeletionProtection:        plan.DeletionProtection,
+		WaitForDetach:             plan.WaitForDetach,
+	})
 	resp.Diagnostics.Append(diags...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *sourceLiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state sourceLiveDataSourceModel
+	var state sourceLiveResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -261,6 +284,11 @@ func (r *sourceLiveResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	source, err := r.client.GetLive(uint(state.ID.ValueInt64()))
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Source live not found, removing from state", map[string]interface{}{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Source Live",
@@ -318,7 +346,7 @@ func (r *sourceLiveResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Set state
-	state = sourceLiveDataSourceModel{
+	state.sourceLiveDataSourceModel = sourceLiveDataSourceModel{
 		ID:          types.Int64Value(int64(source.Id)),
 		Name:        types.StringValue(source.Name),
 		Type:        types.StringValue(source.Type),
@@ -328,6 +356,7 @@ func (r *sourceLiveResource) Read(ctx context.Context, req resource.ReadRequest,
 		MultiPeriod: types.BoolValue(source.MultiPeriod),
 		Origin:      originAttr,
 	}
+	state.DeletionProtection = refreshedDeletionProtection(state.DeletionProtection, r.deletionProtection)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -338,7 +367,7 @@ func (r *sourceLiveResource) Update(ctx context.Context, req resource.UpdateRequ
 	// ---------------------------------------------------------------------
 	// 1. Load the planned state
 	// ---------------------------------------------------------------------
-	var plan sourceLiveDataSourceModel
+	var plan sourceLiveResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -461,22 +490,38 @@ func (r *sourceLiveResource) Update(ctx context.Context, req resource.UpdateRequ
 		Origin:      originAttr,
 	}
 
-	diags = resp.State.Set(ctx, newState)
+	diags = resp.State.Set(ctx, sourceLiveResourceModel{
+		sourceLiveDataSourceModel: newState,
+		DeletionProtection:        plan.DeletionProtection,
+		WaitForDetach:             plan.WaitForDetach,
+	})
 	resp.Diagnostics.Append(diags...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *sourceLiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
-	var state sourceLiveDataSourceModel
+	var state sourceLiveResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if !checkDeletionProtection(ctx, req.State, &resp.Diagnostics, "live source", state.ID.ValueInt64()) {
+		return
+	}
+
 	// Delete existing live
-	_, err := r.client.DeleteLive(uint(state.ID.ValueInt64()))
+	id := uint(state.ID.ValueInt64())
+	err := deleteWhenDetached(ctx, state.WaitForDetach, func() error {
+		_, err := r.client.DeleteLive(id)
+		return err
+	})
+	if isConflict(err) {
+		addSourceInUseError(ctx, &resp.Diagnostics, r.client, "Error Deleting Source Live", "live source", id, err)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Source Live",
@@ -5,19 +5,19 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"testing"
 
+	"bpkio-terraform-provider/internal/bpkiomock"
+
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // 1. Basic creation with required fields.
 func TestAccSourceLive_Basic(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	resourceName := "bpkio_source_live.test"
 	liveName := "tf-acc-test-live-" + randomSuffix()
 
@@ -54,9 +54,6 @@ resource "bpkio_source_live" "test" {
 // 2. Invalid URL (asset does not exist).
 func TestAccSourceLive_InvalidURL(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-invalid-live-" + randomSuffix()
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -86,9 +83,6 @@ resource "bpkio_source_live" "test" {
 // 3. Missing required field (name).
 func TestAccSourceLive_MissingName(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -113,9 +107,6 @@ resource "bpkio_source_live" "test" {
 // 4. Duplicate name+url (if API returns error).
 func TestAccSourceLive_DuplicateNameURL(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	dupName := "tf-acc-dup-live-" + randomSuffix()
 	config := testAccSourceLiveDuplicateConfig(apiKey, dupName)
 
@@ -152,9 +143,6 @@ resource "bpkio_source_live" "second" {
 // 5. Check computed fields are always set.
 func TestAccSourceLive_ComputedFields(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-computed-live-" + randomSuffix()
 	resourceName := "bpkio_source_live.test"
 
@@ -177,9 +165,6 @@ func TestAccSourceLive_ComputedFields(t *testing.T) {
 // 6. Minimal config (omit optional description, multi_period, origin).
 func TestAccSourceLive_MinimalConfig(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-minimal-live-" + randomSuffix()
 	resourceName := "bpkio_source_live.test"
 
@@ -201,9 +186,6 @@ func TestAccSourceLive_MinimalConfig(t *testing.T) {
 // 7. Long names and special characters.
 func TestAccSourceLive_LongSpecialName(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	resourceName := "bpkio_source_live.test"
 	name := fmt.Sprintf("tf-acc-test-live-live-live-live-live-live-live-live-live-live-live-live-live-live-live-live--特殊字符-🚀-%s", randomSuffix())
 
@@ -230,3 +212,86 @@ resource "bpkio_source_live" "test" {
 		},
 	})
 }
+
+// 8. A burst of 429 answers is absorbed by the retry layer.
+func TestAccSourceLive_RateLimitedByAPI(t *testing.T) {
+	if testAccMock == nil {
+		t.Skip("TF_ACC_MOCK=1 is required to inject API failures")
+	}
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_source_live.test"
+	name := "tf-acc-test-live-429-" + randomSuffix()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccMock.InjectFailure(bpkiomock.Failure{
+						Method:     http.MethodPost,
+						PathPrefix: "/v1/sources/live",
+						Status:     http.StatusTooManyRequests,
+						RetryAfter: "0",
+						Count:      3,
+					})
+				},
+				Config: fmt.Sprintf(`
+provider "bpkio" {
+  api_key        = "%s"
+  retry_wait_min = "10ms"
+}
+
+resource "bpkio_source_live" "test" {
+  name = "%s"
+  url  = "https://origin.broadpeak.io/bpk-tv/bpkiofficial/hlsv3/index.m3u8"
+}
+`, apiKey, name),
+				Check: resource.TestCheckResourceAttr(resourceName, "name", name),
+			},
+		},
+	})
+}
+
+// Lifecycle against the in-memory fake: create, update, import, and
+// re-creation after the source is deleted outside of Terraform.
+func TestSourceLiveResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	resourceName := "bpkio_source_live.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceLiveConfig("fake", "live-initial"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "live-initial"),
+					resource.TestCheckResourceAttr(resourceName, "type", "live"),
+					resource.TestCheckResourceAttr(resourceName, "format", "HLS"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			{
+				Config: testAccSourceLiveConfig("fake", "live-updated"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "name", "live-updated"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// The fake gave ID 1 to the only source of the test.
+				PreConfig: func() {
+					if _, err := client.DeleteLive(1); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSourceLiveConfig("fake", "live-updated"),
+				Check:  resource.TestCheckResourceAttrSet(resourceName, "id"),
+			},
+		},
+	})
+}
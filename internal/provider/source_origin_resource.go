@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// NewSourceOriginResource is a helper function to simplify the provider implementation.
+func NewSourceOriginResource() resource.Resource {
+	return &restSourceResource{
+		typeName:    "source_origin",
+		title:       "Source Origin",
+		description: "Manages an origin source, which fronts a CDN origin for the adaptive streaming CDN feature.",
+		url:         "The URL of the origin.",
+		// Custom headers usually carry the credentials of the origin.
+		sensitiveHeaders: true,
+		newModel:         func() restSourceResourceModel { return &restSourceModel{} },
+		api: restSourceMethods{
+			create: apiClient.CreateOrigin,
+			get:    apiClient.GetOrigin,
+			update: apiClient.UpdateOrigin,
+			delete: apiClient.DeleteOrigin,
+		},
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccSourceOrigin_Basic(t *testing.T) {
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_source_origin.test"
+	name := "tf-acc-test-origin-" + randomSuffix()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceOriginConfig(apiKey, name, "secret"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "type", "origin"),
+					resource.TestCheckResourceAttr(resourceName, "origin.custom_headers.0.value", "secret"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSourceOriginConfig(apiKey, name, token string) string {
+	return fmt.Sprintf(`
+provider "bpkio" {
+  api_key = "%s"
+}
+
+resource "bpkio_source_origin" "test" {
+  name = "%s"
+  url  = "https://origin.broadpeak.io/bpk-tv/"
+
+  origin = {
+    custom_headers = [
+      {
+        name  = "X-Origin-Token"
+        value = "%s"
+      },
+    ]
+  }
+}
+
+data "bpkio_source_origin" "test" {
+  id = bpkio_source_origin.test.id
+}
+`, apiKey, name, token)
+}
+
+func TestSourceOriginResource_SensitiveHeaderValues(t *testing.T) {
+	var resp fwresource.SchemaResponse
+	NewSourceOriginResource().Schema(context.Background(), fwresource.SchemaRequest{}, &resp)
+	require.False(t, resp.Diagnostics.HasError())
+
+	value, diags := resp.Schema.AttributeAtPath(context.Background(), path.Root("origin").AtName("custom_headers").AtListIndex(0).AtName("value"))
+	require.False(t, diags.HasError())
+	require.True(t, value.IsSensitive())
+
+	name, diags := resp.Schema.AttributeAtPath(context.Background(), path.Root("origin").AtName("custom_headers").AtListIndex(0).AtName("name"))
+	require.False(t, diags.HasError())
+	require.False(t, name.IsSensitive())
+}
@@ -0,0 +1,355 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &restSourceResource{}
+	_ resource.ResourceWithConfigure   = &restSourceResource{}
+	_ resource.ResourceWithImportState = &restSourceResource{}
+)
+
+// restSourceMethods are the API calls managing one type of REST source.
+type restSourceMethods struct {
+	create func(c apiClient, ctx context.Context, input apiSource) (apiSource, error)
+	get    func(c apiClient, ctx context.Context, id uint) (apiSource, error)
+	update func(c apiClient, ctx context.Context, id uint, input apiSource) (apiSource, error)
+	delete func(c apiClient, ctx context.Context, id uint) error
+}
+
+// restSourceModel maps the attributes shared by the sources managed through
+// the REST endpoints. The model of each type embeds it.
+type restSourceModel struct {
+	ID          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	URL         types.String `tfsdk:"url"`
+	Description types.String `tfsdk:"description"`
+	Origin      types.Object `tfsdk:"origin"`
+}
+
+// restSourceResourceModel is implemented by pointers to the resource model
+// of each type of REST source.
+type restSourceResourceModel interface {
+	// base returns the attributes shared by all types.
+	base() *restSourceModel
+	// expand builds the API input from the model.
+	expand(ctx context.Context) (apiSource, diag.Diagnostics)
+	// flatten sets the model from an API source, leaving the settings that
+	// only exist in Terraform untouched.
+	flatten(source apiSource) diag.Diagnostics
+}
+
+func (m *restSourceModel) base() *restSourceModel {
+	return m
+}
+
+func (m restSourceModel) expand(ctx context.Context) (apiSource, diag.Diagnostics) {
+	origin, diags := expandOrigin(ctx, m.Origin)
+	return apiSource{
+		Name:        m.Name.ValueString(),
+		Description: m.Description.ValueString(),
+		Url:         m.URL.ValueString(),
+		Origin:      origin,
+	}, diags
+}
+
+func (m *restSourceModel) flatten(source apiSource) diag.Diagnostics {
+	origin, diags := flattenOrigin(source.Origin)
+	*m = restSourceModel{
+		ID:          types.Int64Value(int64(source.Id)),
+		Name:        types.StringValue(source.Name),
+		Type:        types.StringValue(source.Type),
+		URL:         types.StringValue(source.Url),
+		Description: types.StringValue(source.Description),
+		Origin:      origin,
+	}
+	return diags
+}
+
+// restSourceResource is the implementation shared by the resources of the
+// sources that the SDK does not cover. Types only differ in their schema,
+// model and API calls.
+type restSourceResource struct {
+	client apiClient
+
+	// typeName is the resource type name without the provider prefix.
+	typeName string
+	// title names the type of source in diagnostics, e.g. "Source Asset",
+	// and in descriptions once lowercased.
+	title       string
+	description string
+	// url describes the url attribute.
+	url string
+	// attributes are the attributes specific to the type.
+	attributes map[string]schema.Attribute
+	// sensitiveHeaders marks the values of the origin custom headers as
+	// sensitive.
+	sensitiveHeaders bool
+	// detachObject names the source in wait_for_detach and in the errors of
+	// sources still in use. Types that services do not reference leave it
+	// empty and have no wait_for_detach.
+	detachObject string
+	// newModel returns an empty resource model of the type.
+	newModel func() restSourceResourceModel
+	api      restSourceMethods
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *restSourceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *restSourceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.typeName
+}
+
+// object names the type of source in messages, e.g. "source asset".
+func (r *restSourceResource) object() string {
+	return strings.ToLower(r.title)
+}
+
+// Schema defines the schema for the resource.
+func (r *restSourceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The ID of the %s.", r.object()),
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: fmt.Sprintf("The name of the %s.", r.object()),
+		},
+		"type": schema.StringAttribute{
+			Computed:    true,
+			Description: fmt.Sprintf("The type of the %s.", r.object()),
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"url": schema.StringAttribute{
+			Required:    true,
+			Description: r.url,
+		},
+		"description": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: fmt.Sprintf("The description of the %s.", r.object()),
+			Default:     stringdefault.StaticString(""),
+		},
+		"origin": originResourceAttribute(r.object(), r.sensitiveHeaders),
+	}
+	if r.detachObject != "" {
+		attributes["wait_for_detach"] = waitForDetachAttribute(r.detachObject)
+	}
+	maps.Copy(attributes, r.attributes)
+
+	resp.Schema = schema.Schema{
+		Description: r.description,
+		Attributes:  attributes,
+	}
+}
+
+// flatten sets m from source. An empty origin is kept as configured in
+// prior, see keepEmptyOrigin.
+func (r *restSourceResource) flatten(m restSourceResourceModel, source apiSource, prior types.Object) diag.Diagnostics {
+	diags := m.flatten(source)
+	m.base().Origin = keepEmptyOrigin(m.base().Origin, prior)
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *restSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve the plan into a strongly typed model
+	plan := r.newModel()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build the API input from the Terraform plan
+	input, diags := plan.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call the Broadpeak API to create the resource
+	source, err := r.api.create(r.client, ctx, input)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error Creating "+r.title, "Could not create "+r.object(), err)
+		return
+	}
+
+	// Save the state
+	resp.Diagnostics.Append(r.flatten(plan, source, plan.base().Origin)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *restSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state := r.newModel()
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.base().ID.ValueInt64()
+	source, err := r.api.get(r.client, ctx, uint(id))
+	if isNotFound(err) {
+		tflog.Warn(ctx, r.title+" not found, removing from state", map[string]interface{}{"id": id})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read "+r.title,
+			fmt.Sprintf("Could not read %s ID %d: %s", r.object(), id, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.flatten(state, source, state.base().Origin)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *restSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// ---------------------------------------------------------------------
+	// 1. Load the planned state
+	// ---------------------------------------------------------------------
+	plan := r.newModel()
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ---------------------------------------------------------------------
+	// 2. Build the input for the Broadpeak API
+	// ---------------------------------------------------------------------
+	input, diags := plan.expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ---------------------------------------------------------------------
+	// 3. Call the API to update
+	// ---------------------------------------------------------------------
+	id := uint(plan.base().ID.ValueInt64())
+	source, err := r.api.update(r.client, ctx, id, input)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error Updating "+r.title, fmt.Sprintf("Could not update %s ID %d", r.object(), id), err)
+		return
+	}
+
+	// ---------------------------------------------------------------------
+	// 4. Write final state
+	// ---------------------------------------------------------------------
+	resp.Diagnostics.Append(r.flatten(plan, source, plan.base().Origin)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *restSourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	state := r.newModel()
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var waitForDetach types.String
+	if r.detachObject != "" {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("wait_for_detach"), &waitForDetach)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Delete existing source
+	id := uint(state.base().ID.ValueInt64())
+	err := deleteWhenDetached(ctx, waitForDetach, func() error {
+		return r.api.delete(r.client, ctx, id)
+	})
+	if isConflict(err) && r.detachObject != "" {
+		addSourceInUseError(ctx, &resp.Diagnostics, r.client, "Error Deleting "+r.title, r.detachObject, id, err)
+		return
+	}
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting "+r.title,
+			fmt.Sprintf("Could not delete %s, unexpected error: %s", r.object(), err),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource state from the ID.
+func (r *restSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing "+r.object(),
+			fmt.Sprintf("Invalid ID format: %s. Expected a numeric ID. Error: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
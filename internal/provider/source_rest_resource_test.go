@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTSourceResource(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	r := NewSourceAssetResource().(*restSourceResource)
+	r.client = client
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, sourceAssetResourceModel{
+		sourceAssetModel: sourceAssetModel{restSourceModel: restSourceModel{
+			ID:          types.Int64Unknown(),
+			Name:        types.StringValue("asset"),
+			Type:        types.StringUnknown(),
+			URL:         types.StringValue("https://origin.example.com/movie.m3u8"),
+			Description: types.StringValue(""),
+			Origin:      types.ObjectNull(originAttrTypes),
+		}, Format: types.StringUnknown()},
+		WaitForDetach: types.StringValue("1m"),
+	}).HasError())
+
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), createResp.Diagnostics)
+
+	var created sourceAssetResourceModel
+	require.False(t, createResp.State.Get(ctx, &created).HasError())
+	require.Equal(t, "asset", created.Type.ValueString())
+	require.Equal(t, "HLS", created.Format.ValueString())
+	require.Equal(t, "1m", created.WaitForDetach.ValueString(), "settings only known to Terraform are kept")
+	require.True(t, created.Origin.IsNull(), "an unset origin stays unset")
+
+	id := uint(created.ID.ValueInt64())
+	require.NoError(t, client.DeleteAsset(ctx, id))
+	readResp := fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError())
+	require.True(t, readResp.State.Raw.IsNull(), "a source deleted outside Terraform is removed from state")
+
+	var deleteResp fwresource.DeleteResponse
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "deleting a missing source succeeds")
+
+	importResp := fwresource.ImportStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "asset"}, &importResp)
+	require.True(t, importResp.Diagnostics.HasError())
+}
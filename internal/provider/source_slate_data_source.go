@@ -23,7 +23,7 @@ var (
 
 // sourceSlateDataSource is the data source implementation.
 type sourceSlateDataSource struct {
-	client *broadpeakio.BroadpeakClient
+	client apiClient
 }
 
 // NewSourceSlateDataSource is a helper function to simplify the provider implementation.
@@ -39,11 +39,11 @@ func (d *sourceSlateDataSource) Configure(_ context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
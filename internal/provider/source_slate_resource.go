@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -25,6 +26,7 @@ var (
 	_ resource.Resource                = &sourceSlateResource{}
 	_ resource.ResourceWithConfigure   = &sourceSlateResource{}
 	_ resource.ResourceWithImportState = &sourceSlateResource{}
+	_ resource.ResourceWithModifyPlan  = &sourceSlateResource{}
 )
 
 // NewSourceSlateResource is a helper function to simplify the provider implementation.
@@ -34,7 +36,16 @@ func NewSourceSlateResource() resource.Resource {
 
 // sourceSlateResource is the resource implementation.
 type sourceSlateResource struct {
-	client *broadpeakio.BroadpeakClient
+	client             apiClient
+	deletionProtection bool
+}
+
+// sourceSlateResourceModel maps the resource schema data: the data source
+// model and the settings that only exist on the resource.
+type sourceSlateResourceModel struct {
+	sourceSlateDataSourceModel
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	WaitForDetach      types.String `tfsdk:"wait_for_detach"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -44,17 +55,18 @@ func (r *sourceSlateResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
 	r.client = client
+	r.deletionProtection = providerDeletionProtection(req.ProviderData)
 }
 
 // Metadata returns the resource type name.
@@ -101,14 +113,21 @@ func (r *sourceSlateResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Computed:    true,
 				Description: "The format of the slate.",
 			},
+			"deletion_protection": deletionProtectionAttribute("slate"),
+			"wait_for_detach":     waitForDetachAttribute("slate"),
 		},
 	}
 }
 
+// ModifyPlan plans the provider default of deletion_protection.
+func (r *sourceSlateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDeletionProtection(ctx, req, resp, r.deletionProtection)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *sourceSlateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
-	var plan sourceSlateDataSourceModel
+	var plan sourceSlateResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -132,7 +151,7 @@ func (r *sourceSlateResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Map response body to schema and populate Computed attribute values
-	plan = sourceSlateDataSourceModel{
+	plan.sourceSlateDataSourceModel = sourceSlateDataSourceModel{
 		ID:          types.Int64Value(int64(source.Id)),
 		Name:        types.StringValue(source.Name),
 		Type:        types.StringValue(source.Type),
@@ -152,7 +171,7 @@ func (r *sourceSlateResource) Create(ctx context.Context, req resource.CreateReq
 // Read refreshes the Terraform state with the latest data.
 func (r *sourceSlateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// Get current state
-	var state sourceSlateDataSourceModel
+	var state sourceSlateResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -161,6 +180,11 @@ func (r *sourceSlateResource) Read(ctx context.Context, req resource.ReadRequest
 
 	// Get refreshed slate value from HashiCups
 	source, err := r.client.GetSlate(uint(state.ID.ValueInt64()))
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Source slate not found, removing from state", map[string]interface{}{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Single Service",
@@ -169,7 +193,7 @@ func (r *sourceSlateResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	state = sourceSlateDataSourceModel{
+	state.sourceSlateDataSourceModel = sourceSlateDataSourceModel{
 		ID:          types.Int64Value(int64(source.Id)),
 		Name:        types.StringValue(source.Name),
 		Type:        types.StringValue(source.Type),
@@ -177,6 +201,7 @@ func (r *sourceSlateResource) Read(ctx context.Context, req resource.ReadRequest
 		Description: types.StringValue(source.Description),
 		Format:      types.StringValue(source.Format),
 	}
+	state.DeletionProtection = refreshedDeletionProtection(state.DeletionProtection, r.deletionProtection)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -189,7 +214,7 @@ func (r *sourceSlateResource) Read(ctx context.Context, req resource.ReadRequest
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *sourceSlateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Retrieve values from plan and current state
-	var plan sourceSlateDataSourceModel
+	var plan sourceSlateResourceModel
 
 	// Get planned changes
 	diags := req.Plan.Get(ctx, &plan)
@@ -236,7 +261,11 @@ func (r *sourceSlateResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	// Set state to fully populated data
-	diags = resp.State.Set(ctx, result)
+	diags = resp.State.Set(ctx, sourceSlateResourceModel{
+		sourceSlateDataSourceModel: result,
+		DeletionProtection:         plan.DeletionProtection,
+		WaitForDetach:              plan.WaitForDetach,
+	})
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -246,15 +275,27 @@ func (r *sourceSlateResource) Update(ctx context.Context, req resource.UpdateReq
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *sourceSlateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
-	var state sourceSlateDataSourceModel
+	var state sourceSlateResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if !checkDeletionProtection(ctx, req.State, &resp.Diagnostics, "slate", state.ID.ValueInt64()) {
+		return
+	}
+
 	// Delete existing slate
-	_, err := r.client.DeleteSlate(uint(state.ID.ValueInt64()))
+	id := uint(state.ID.ValueInt64())
+	err := deleteWhenDetached(ctx, state.WaitForDetach, func() error {
+		_, err := r.client.DeleteSlate(id)
+		return err
+	})
+	if isConflict(err) {
+		addSourceInUseError(ctx, &resp.Diagnostics, r.client, "Error Deleting Source Slate", "slate", id, err)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Source Slate",
@@ -14,9 +14,6 @@ import (
 
 func TestAccSourceSlate_Basic(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	resourceName := "bpkio_source_slate.test"
 	name := "tf-acc-test-slate-" + randomSuffix()
 
@@ -52,9 +49,6 @@ resource "bpkio_source_slate" "test" {
 
 func TestAccSourceSlate_InvalidURL(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	badURL := "https://this-url-does-not-exist.broadpeak.io/foo.jpg"
 	name := "tf-acc-test-invalid-" + randomSuffix()
@@ -82,9 +76,6 @@ resource "bpkio_source_slate" "test" {
 
 func TestAccSourceSlate_Update(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	resourceName := "bpkio_source_slate.test"
 	initialName := "tf-acc-test-slate-update-" + randomSuffix()
@@ -128,9 +119,6 @@ resource "bpkio_source_slate" "test" {
 
 func TestAccSourceSlate_Import(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 
 	resourceName := "bpkio_source_slate.test"
 	name := "tf-acc-test-slate-import-" + randomSuffix()
@@ -159,9 +147,6 @@ resource "bpkio_source_slate" "test" {
 
 func TestAccSourceSlate_MissingName(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProviderFactories(),
@@ -181,9 +166,6 @@ resource "bpkio_source_slate" "test" {
 
 func TestAccSourceSlate_MissingURL(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-test-missing-url-" + randomSuffix()
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -204,9 +186,6 @@ resource "bpkio_source_slate" "test" {
 
 func TestAccSourceSlate_DuplicateNameURL(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	name := "tf-acc-test-duplicate-" + randomSuffix()
 	url := "https://bpkiosamples.s3.eu-west-1.amazonaws.com/broadpeakio-slate.jpg"
 
@@ -235,9 +214,6 @@ resource "bpkio_source_slate" "second" {
 
 func TestAccSourceSlate_ComputedFields(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	resourceName := "bpkio_source_slate.test"
 	name := "tf-acc-test-computed-" + randomSuffix()
 	resource.Test(t, resource.TestCase{
@@ -258,9 +234,6 @@ func TestAccSourceSlate_ComputedFields(t *testing.T) {
 
 func TestAccSourceSlate_MinimalConfig(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	resourceName := "bpkio_source_slate.minimal"
 	name := "tf-acc-test-minimal-" + randomSuffix()
 	resource.Test(t, resource.TestCase{
@@ -288,9 +261,6 @@ resource "bpkio_source_slate" "minimal" {
 
 func TestAccSourceSlate_LongNameAndSpecialChars(t *testing.T) {
 	apiKey := os.Getenv("BPKIO_API_KEY")
-	if apiKey == "" {
-		t.Fatal("BPKIO_API_KEY must be set for acceptance tests")
-	}
 	longName := fmt.Sprintf("tf-acc-test-超级长的名字-🚀-%s", randomSuffix())
 	resourceName := "bpkio_source_slate.special"
 	resource.Test(t, resource.TestCase{
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	broadpeakio "github.com/bashou/bpkio-go-sdk"
 
@@ -24,7 +25,7 @@ var (
 
 // sourcesDataSource is the data source implementation.
 type sourcesDataSource struct {
-	client *broadpeakio.BroadpeakClient
+	client apiClient
 }
 
 // NewSourcesDataSource is a helper function to simplify the provider implementation.
@@ -40,11 +41,11 @@ func (d *sourcesDataSource) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	client, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -63,11 +64,13 @@ func (d *sourcesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"type": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Only return sources of this type: " + quotedList(sourceTypeNames) + ".",
 				Validators: []validator.String{
-					stringvalidator.OneOf("live", "asset", "asset-catalog", "slate", "ad-server"),
+					stringvalidator.OneOf(sourceTypeNames...),
 				},
 			},
+			"max_results": maxResultsAttribute("sources"),
 			"sources": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -98,7 +101,9 @@ func (d *sourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
-	sources, err := d.client.GetAllSources(0, 2000)
+	sources, truncated, err := listAll(d.client.GetAllSources, state.MaxResults.ValueInt64(), func(source broadpeakio.Source) bool {
+		return state.Type.IsNull() || source.Type == state.Type.ValueString()
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -117,9 +122,10 @@ func (d *sourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 			URL:  types.StringValue(source.Url),
 		}
 
-		if state.Type.IsNull() || (source.Type == state.Type.ValueString() && !state.Type.IsNull()) {
-			state.Sources = append(state.Sources, sourceState)
-		}
+		state.Sources = append(state.Sources, sourceState)
+	}
+	if truncated {
+		addTruncationWarning(&resp.Diagnostics, state.MaxResults, "sources")
 	}
 
 	// Set state
@@ -152,10 +158,23 @@ func flattenSources(sdkSources []broadpeakio.Source, filterType *string) []sourc
 	return result
 }
 
+// sourceTypeNames are the source types accepted by the `type` filter.
+var sourceTypeNames = []string{"live", "asset", "asset-catalog", "origin", "slate", "ad-server"}
+
+// quotedList formats values as a comma separated list of code spans.
+func quotedList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "`" + v + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // sourcesDataSourceModel maps the data source schema data.
 type sourcesDataSourceModel struct {
-	Type    types.String   `tfsdk:"type"`
-	Sources []sourcesModel `tfsdk:"sources"`
+	Type       types.String   `tfsdk:"type"`
+	MaxResults types.Int64    `tfsdk:"max_results"`
+	Sources    []sourcesModel `tfsdk:"sources"`
 }
 
 // sourcesModel maps sources schema data.
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// tenant is the subset of the Broadpeak tenant object used by the provider.
+type tenant struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// fetchCurrentTenant returns the tenant owning apiKey. It is the cheapest
+// authenticated call of the API and is used to validate credentials.
+func fetchCurrentTenant(ctx context.Context, httpClient *http.Client, endpoint, apiKey string) (*tenant, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/v1/tenants/me", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, &apiError{StatusCode: res.StatusCode, Body: string(body)}
+	}
+
+	var t tenant
+	if err := json.Unmarshal(body, &t); err != nil {
+		return nil, fmt.Errorf("decoding tenant: %w", err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchCurrentTenant(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/tenants/me" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer good-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":42,"name":"acme"}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	t.Run("valid key", func(t *testing.T) {
+		got, err := fetchCurrentTenant(ctx, srv.Client(), srv.URL, "good-key")
+		require.NoError(t, err)
+		require.Equal(t, &tenant{Id: 42, Name: "acme"}, got)
+	})
+
+	t.Run("rejected key", func(t *testing.T) {
+		_, err := fetchCurrentTenant(ctx, srv.Client(), srv.URL, "bad-key")
+		var apiErr *apiError
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		require.Contains(t, err.Error(), "401 Unauthorized")
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the tflog subsystem used for API traffic. Its level can
+// be set on its own with TF_LOG_PROVIDER_BPKIO_HTTP.
+const httpLogSubsystem = "bpkio_http"
+
+// redactedValue replaces secrets in logged headers and bodies.
+const redactedValue = "***"
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ http.RoundTripper = &traceTransport{}
+
+// newHTTPLogContext returns a context carrying the bpkio_http subsystem
+// logger. The SDK does not propagate contexts to its HTTP requests, so the
+// transports log through this context rather than the request's.
+func newHTTPLogContext(ctx context.Context, apiKey string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_BPKIO", "http"))
+	if apiKey != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, httpLogSubsystem, apiKey)
+	}
+	return ctx
+}
+
+// traceTransport logs every Broadpeak API call at TRACE level, with the
+// credentials and secret values found in headers and bodies redacted.
+type traceTransport struct {
+	next   http.RoundTripper
+	logCtx context.Context
+}
+
+func newTraceTransport(logCtx context.Context, next http.RoundTripper) *traceTransport {
+	return &traceTransport{
+		next:   next,
+		logCtx: logCtx,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"method":          req.Method,
+		"path":            req.URL.RequestURI(),
+		"request_headers": redactHeaders(req.Header),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields["request_body"] = redactBody(body)
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemTrace(t.logCtx, httpLogSubsystem, "bpkio API request failed", fields)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	fields["status"] = res.StatusCode
+	fields["response_body"] = redactBody(body)
+	tflog.SubsystemTrace(t.logCtx, httpLogSubsystem, "bpkio API response", fields)
+
+	return res, nil
+}
+
+// redactHeaders flattens headers for logging, hiding credentials.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		switch strings.ToLower(name) {
+		case "authorization", "x-api-key", "cookie", "set-cookie":
+			out[name] = redactedValue
+		default:
+			out[name] = strings.Join(values, ", ")
+		}
+	}
+	return out
+}
+
+// redactBody returns a JSON body as a string with secret values hidden:
+// the ad insertion authorization header value and the origin custom header
+// values. Bodies that are not JSON are returned unchanged.
+func redactBody(body []byte) string {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return string(body)
+	}
+	redactJSON(doc)
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return string(body)
+	}
+	return string(out)
+}
+
+func redactJSON(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			switch key {
+			case "authorizationHeader":
+				redactValueField(child)
+			case "customHeaders":
+				if headers, ok := child.([]interface{}); ok {
+					for _, h := range headers {
+						redactValueField(h)
+					}
+				}
+			}
+			redactJSON(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			redactJSON(child)
+		}
+	}
+}
+
+// redactValueField hides the "value" member of a name/value header object.
+func redactValueField(v interface{}) {
+	if obj, ok := v.(map[string]interface{}); ok {
+		if _, ok := obj["value"]; ok {
+			obj["value"] = redactedValue
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":7,"origin":{"customHeaders":[{"name":"X-Token","value":"origin-secret"}]}}`))
+	}))
+	defer srv.Close()
+
+	var output bytes.Buffer
+	logCtx := newHTTPLogContext(tflogtest.RootLogger(context.Background(), &output), "the-api-key")
+	client := &http.Client{Transport: newTraceTransport(logCtx, http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/v1/services/ad-insertion",
+		strings.NewReader(`{"name":"svc","advancedOptions":{"authorizationHeader":{"name":"X-Auth","value":"auth-secret"}}}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer the-api-key")
+
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	// The response body is still readable by the caller.
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "origin-secret")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	entry := entries[0]
+	require.Equal(t, "bpkio API response", entry["@message"])
+	require.Equal(t, "provider."+httpLogSubsystem, entry["@module"])
+	require.Equal(t, "POST", entry["method"])
+	require.Equal(t, "/v1/services/ad-insertion", entry["path"])
+	require.Equal(t, float64(http.StatusCreated), entry["status"])
+	require.Contains(t, entry, "latency_ms")
+
+	raw := output.String()
+	require.NotContains(t, raw, "the-api-key")
+	require.NotContains(t, raw, "auth-secret")
+	require.NotContains(t, raw, "origin-secret")
+	require.Contains(t, entry["request_body"], `"name":"X-Auth"`)
+	require.Contains(t, entry["response_body"], `"name":"X-Token"`)
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "authorization header",
+			in:   `{"advancedOptions":{"authorizationHeader":{"name":"X-Auth","value":"secret"}}}`,
+			want: `{"advancedOptions":{"authorizationHeader":{"name":"X-Auth","value":"***"}}}`,
+		},
+		{
+			name: "nested custom headers in list",
+			in:   `[{"source":{"origin":{"customHeaders":[{"name":"A","value":"1"},{"name":"B","value":"2"}]}}}]`,
+			want: `[{"source":{"origin":{"customHeaders":[{"name":"A","value":"***"},{"name":"B","value":"***"}]}}}]`,
+		},
+		{
+			name: "other values untouched",
+			in:   `{"queryParameters":[{"name":"a","value":"b"}]}`,
+			want: `{"queryParameters":[{"name":"a","value":"b"}]}`,
+		},
+		{
+			name: "not json",
+			in:   `Bad Gateway`,
+			want: `Bad Gateway`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, redactBody([]byte(tt.in)))
+		})
+	}
+}
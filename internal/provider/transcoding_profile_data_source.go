@@ -28,7 +28,7 @@ var (
 // Data-source definition.
 // --------------------------------------------------------------------.
 type transcodingProfileDataSource struct {
-	client *broadpeakio.BroadpeakClient
+	client apiClient
 }
 
 func NewTranscodingProfileDataSource() datasource.DataSource {
@@ -46,11 +46,11 @@ func (d *transcodingProfileDataSource) Configure(
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	c, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Provider Data Type",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got %T", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got %T", req.ProviderData),
 		)
 		return
 	}
@@ -86,7 +86,8 @@ func (d *transcodingProfileDataSource) Schema(
 			},
 			// We keep raw JSON as a string for simplicity
 			"content": schema.StringAttribute{
-				Computed: true,
+				CustomType: jsonStringType{},
+				Computed:   true,
 			},
 			"internal_id": schema.StringAttribute{
 				Computed: true,
@@ -125,7 +126,7 @@ func (d *transcodingProfileDataSource) Read(
 	state := transcodingProfileDataSourceModel{
 		ID:         types.Int64Value(int64(p.Id)),
 		Name:       types.StringValue(p.Name),
-		Content:    types.StringValue(string(p.Content)),
+		Content:    newJSONStringValue(p.Content),
 		InternalId: types.StringValue(p.InternalId),
 	}
 
@@ -137,10 +138,10 @@ func (d *transcodingProfileDataSource) Read(
 // State model.
 // --------------------------------------------------------------------.
 type transcodingProfileDataSourceModel struct {
-	ID         types.Int64  `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	Content    types.String `tfsdk:"content"`
-	InternalId types.String `tfsdk:"internal_id"`
+	ID         types.Int64     `tfsdk:"id"`
+	Name       types.String    `tfsdk:"name"`
+	Content    jsonStringValue `tfsdk:"content"`
+	InternalId types.String    `tfsdk:"internal_id"`
 }
 
 func FlattenTranscodingProfiles(list []broadpeakio.TranscodingProfile) ([]attr.Value, attr.Type, error) {
@@ -148,7 +149,7 @@ func FlattenTranscodingProfiles(list []broadpeakio.TranscodingProfile) ([]attr.V
 		AttrTypes: map[string]attr.Type{
 			"id":          types.Int64Type,
 			"name":        types.StringType,
-			"content":     types.StringType,
+			"content":     jsonStringType{},
 			"internal_id": types.StringType,
 		},
 	}
@@ -158,7 +159,7 @@ func FlattenTranscodingProfiles(list []broadpeakio.TranscodingProfile) ([]attr.V
 		obj, diag := types.ObjectValue(profileType.AttrTypes, map[string]attr.Value{
 			"id":          types.Int64Value(int64(p.Id)),
 			"name":        types.StringValue(p.Name),
-			"content":     types.StringValue(p.Content),
+			"content":     newJSONStringValue(p.Content),
 			"internal_id": types.StringValue(p.InternalId),
 		})
 		if diag.HasError() {
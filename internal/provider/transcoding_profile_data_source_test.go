@@ -54,6 +54,8 @@ func TestFlattenTranscodingProfiles(t *testing.T) {
 				switch val := v.(type) {
 				case types.String:
 					m[k] = val.ValueString()
+				case jsonStringValue:
+					m[k] = val.ValueString()
 				case types.Int64:
 					m[k] = fmt.Sprintf("%d", val.ValueInt64())
 				default:
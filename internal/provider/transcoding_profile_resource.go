@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &transcodingProfileResource{}
+	_ resource.ResourceWithConfigure   = &transcodingProfileResource{}
+	_ resource.ResourceWithImportState = &transcodingProfileResource{}
+)
+
+// NewTranscodingProfileResource is a helper function to simplify the provider implementation.
+func NewTranscodingProfileResource() resource.Resource {
+	return &transcodingProfileResource{}
+}
+
+// transcodingProfileResource is the resource implementation.
+type transcodingProfileResource struct {
+	client apiClient
+}
+
+// transcodingProfileResourceModel maps the resource schema data.
+type transcodingProfileResourceModel struct {
+	ID         types.Int64     `tfsdk:"id"`
+	Name       types.String    `tfsdk:"name"`
+	Content    jsonStringValue `tfsdk:"content"`
+	InternalID types.String    `tfsdk:"internal_id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *transcodingProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *transcodingProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_transcoding_profile"
+}
+
+// Schema defines the schema for the resource.
+func (r *transcodingProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a transcoding profile, used to transcode ads to the renditions of the services that reference it. " +
+			"Only tenants that are allowed to manage transcoding profiles can create them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The ID of the transcoding profile.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the transcoding profile.",
+			},
+			"content": schema.StringAttribute{
+				CustomType:  jsonStringType{},
+				Required:    true,
+				Description: "The profile definition, as a JSON document. Use `jsonencode` to build it from an HCL object. Documents that only differ in key order or whitespace are equal.",
+			},
+			"internal_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The internal ID of the transcoding profile on the Broadpeak side.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// expand builds the API input from the Terraform plan.
+func (m transcodingProfileResourceModel) expand() apiTranscodingProfileInput {
+	return apiTranscodingProfileInput{
+		Name:    m.Name.ValueString(),
+		Content: m.Content.ValueString(),
+	}
+}
+
+// flattenTranscodingProfile converts an API transcoding profile into its
+// Terraform model.
+func flattenTranscodingProfile(profile apiTranscodingProfile) transcodingProfileResourceModel {
+	return transcodingProfileResourceModel{
+		ID:         types.Int64Value(int64(profile.Id)),
+		Name:       types.StringValue(profile.Name),
+		Content:    newJSONStringValue(profile.Content),
+		InternalID: types.StringValue(profile.InternalId),
+	}
+}
+
+// transcodingProfileErrorDetail explains the 403 answered to tenants that
+// may not manage transcoding profiles, which is otherwise a bare Forbidden.
+func transcodingProfileErrorDetail(detail string, err error) string {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+		return detail + ". Check that the tenant of the API key is allowed to manage transcoding profiles"
+	}
+	return detail
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *transcodingProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve the plan into a strongly typed model
+	var plan transcodingProfileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call the Broadpeak API to create the resource
+	profile, err := r.client.CreateTranscodingProfile(ctx, plan.expand())
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error creating transcoding profile",
+			transcodingProfileErrorDetail("Could not create transcoding profile", err), err)
+		return
+	}
+
+	// Save the state
+	state := flattenTranscodingProfile(profile)
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *transcodingProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state transcodingProfileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, err := r.client.GetTranscodingProfile(uint(state.ID.ValueInt64()))
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Transcoding profile not found, removing from state", map[string]interface{}{"id": state.ID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Transcoding Profile",
+			fmt.Sprintf("Could not read transcoding profile ID %d: %s", state.ID.ValueInt64(), err),
+		)
+		return
+	}
+
+	state = flattenTranscodingProfile(apiTranscodingProfile{
+		Id:         profile.Id,
+		Name:       profile.Name,
+		Content:    profile.Content,
+		InternalId: profile.InternalId,
+	})
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *transcodingProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan transcodingProfileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profileID := uint(plan.ID.ValueInt64())
+	profile, err := r.client.UpdateTranscodingProfile(ctx, profileID, plan.expand())
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, "Error Updating Transcoding Profile",
+			transcodingProfileErrorDetail(fmt.Sprintf("Could not update transcoding profile ID %d", profileID), err), err)
+		return
+	}
+
+	newState := flattenTranscodingProfile(profile)
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *transcodingProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state transcodingProfileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete existing transcoding profile
+	err := r.client.DeleteTranscodingProfile(ctx, uint(state.ID.ValueInt64()))
+	if err != nil && !isNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Transcoding Profile",
+			transcodingProfileErrorDetail("Could not delete transcoding profile", err)+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the resource state from the ID.
+func (r *transcodingProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing transcoding profile",
+			fmt.Sprintf("Invalid ID format: %s. Expected a numeric ID. Error: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccTranscodingProfile_Basic(t *testing.T) {
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_transcoding_profile.test"
+	name := "tf-acc-test-profile-" + randomSuffix()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTranscodingProfileConfig(apiKey, name, "4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "internal_id"),
+				),
+			},
+			{
+				Config: testAccTranscodingProfileConfig(apiKey, name, "6"),
+				Check:  resource.TestCheckResourceAttrPair(resourceName, "content", "data.bpkio_transcoding_profile.test", "content"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccTranscodingProfileConfig(apiKey, name, hlsVersion string) string {
+	return fmt.Sprintf(`
+provider "bpkio" {
+  api_key = "%s"
+}
+
+resource "bpkio_transcoding_profile" "test" {
+  name = "%s"
+  content = jsonencode({
+    packaging = {
+      "--hls-client-manifest-version=" = "%s"
+    }
+    servicetype = "offline_transcoding"
+    transcoding = {
+      common = {}
+      jobs   = []
+    }
+  })
+}
+
+data "bpkio_transcoding_profile" "test" {
+  id = bpkio_transcoding_profile.test.id
+}
+`, apiKey, name, hlsVersion)
+}
+
+func TestTranscodingProfileResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	resourceName := "bpkio_transcoding_profile.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTranscodingProfileConfig("fake", "profile", "4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "profile"),
+					resource.TestCheckResourceAttrSet(resourceName, "internal_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "content", "data.bpkio_transcoding_profile.test", "content"),
+				),
+			},
+			{
+				Config: testAccTranscodingProfileConfig("fake", "renamed", "6"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "renamed"),
+					resource.TestCheckResourceAttrPair(resourceName, "content", "data.bpkio_transcoding_profile.test", "content"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// The API may re-serialize the document; that must not show up as a diff.
+func TestTranscodingProfileResource_ReformattedContent(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTranscodingProfileConfig("fake", "profile", "4"),
+			},
+			{
+				PreConfig: func() {
+					client.mu.Lock()
+					defer client.mu.Unlock()
+					for id, profile := range client.transcodingProfiles {
+						var indented bytes.Buffer
+						require.NoError(t, json.Indent(&indented, []byte(profile.Content), "", "  "))
+						profile.Content = indented.String()
+						client.transcodingProfiles[id] = profile
+					}
+				},
+				Config:   testAccTranscodingProfileConfig("fake", "profile", "4"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestTranscodingProfileResource_InvalidContent(t *testing.T) {
+	testUnitPreCheck(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(newFakeClient()),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "bpkio" {
+  api_key = "fake"
+}
+
+resource "bpkio_transcoding_profile" "test" {
+  name    = "profile"
+  content = "{not json"
+}
+`,
+				ExpectError: regexp.MustCompile(`content must be a valid JSON string`),
+			},
+		},
+	})
+}
+
+func TestTranscodingProfileErrorDetail(t *testing.T) {
+	forbidden := &apiError{StatusCode: http.StatusForbidden}
+	require.Contains(t, transcodingProfileErrorDetail("Could not create transcoding profile", forbidden), "allowed to manage transcoding profiles")
+
+	invalid := &apiError{StatusCode: http.StatusBadRequest}
+	require.Equal(t, "Could not create transcoding profile", transcodingProfileErrorDetail("Could not create transcoding profile", invalid))
+}
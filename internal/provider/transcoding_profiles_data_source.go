@@ -7,8 +7,6 @@ import (
 	"context"
 	"fmt"
 
-	broadpeakio "github.com/bashou/bpkio-go-sdk"
-
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -27,7 +25,7 @@ var (
 // Data-source definition.
 // --------------------------------------------------------------------.
 type transcodingProfilesDataSource struct {
-	client *broadpeakio.BroadpeakClient
+	client apiClient
 }
 
 func NewTranscodingProfilesDataSource() datasource.DataSource {
@@ -45,11 +43,11 @@ func (d *transcodingProfilesDataSource) Configure(
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*broadpeakio.BroadpeakClient)
+	c, ok := req.ProviderData.(apiClient)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Provider Data",
-			fmt.Sprintf("Expected *broadpeakio.BroadpeakClient, got %T", req.ProviderData),
+			fmt.Sprintf("Expected a bpkio API client, got %T", req.ProviderData),
 		)
 		return
 	}
@@ -77,13 +75,14 @@ func (d *transcodingProfilesDataSource) Schema(
 ) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"max_results": maxResultsAttribute("transcoding profiles"),
 			"profiles": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id":          schema.Int64Attribute{Computed: true},
 						"name":        schema.StringAttribute{Computed: true},
-						"content":     schema.StringAttribute{Computed: true},
+						"content":     schema.StringAttribute{CustomType: jsonStringType{}, Computed: true},
 						"internal_id": schema.StringAttribute{Computed: true},
 					},
 				},
@@ -95,22 +94,31 @@ func (d *transcodingProfilesDataSource) Schema(
 // Read.
 func (d *transcodingProfilesDataSource) Read(
 	ctx context.Context,
-	_ datasource.ReadRequest,
+	req datasource.ReadRequest,
 	resp *datasource.ReadResponse,
 ) {
+	var config transcodingProfilesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// 1. Call the Broadpeak API.
-	list, err := d.client.GetAllTranscodingProfiles(0, 2000)
+	list, truncated, err := listAll(d.client.GetAllTranscodingProfiles, config.MaxResults.ValueInt64(), nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to List Transcoding Profiles", err.Error())
 		return
 	}
+	if truncated {
+		addTruncationWarning(&resp.Diagnostics, config.MaxResults, "transcoding profiles")
+	}
 
 	// 2. Build Terraform-typed list.
 	profileObjType := types.ObjectType{
 		AttrTypes: map[string]attr.Type{
 			"id":          types.Int64Type,
 			"name":        types.StringType,
-			"content":     types.StringType,
+			"content":     jsonStringType{},
 			"internal_id": types.StringType,
 		},
 	}
@@ -122,7 +130,7 @@ func (d *transcodingProfilesDataSource) Read(
 			map[string]attr.Value{
 				"id":          types.Int64Value(int64(p.Id)),
 				"name":        types.StringValue(p.Name),
-				"content":     types.StringValue(string(p.Content)),
+				"content":     newJSONStringValue(p.Content),
 				"internal_id": types.StringValue(p.InternalId),
 			},
 		)
@@ -142,7 +150,8 @@ func (d *transcodingProfilesDataSource) Read(
 
 	// 3. Set state.
 	state := transcodingProfilesDataSourceModel{
-		Profiles: profilesList,
+		MaxResults: config.MaxResults,
+		Profiles:   profilesList,
 	}
 	diag := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diag...)
@@ -152,5 +161,6 @@ func (d *transcodingProfilesDataSource) Read(
 // State model.
 // --------------------------------------------------------------------.
 type transcodingProfilesDataSourceModel struct {
-	Profiles types.List `tfsdk:"profiles"` // List<Object>
+	MaxResults types.Int64 `tfsdk:"max_results"`
+	Profiles   types.List  `tfsdk:"profiles"` // List<Object>
 }
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// apiFieldError is a validation message returned by the Broadpeak API. Field
+// is the API path of the rejected value (e.g. `liveAdReplacement.gapFiller`)
+// and is empty when the message does not name one.
+type apiFieldError struct {
+	Field   string
+	Message string
+}
+
+// apiFieldRenames lists the API field names whose Terraform attribute is not
+// simply their snake_case form.
+var apiFieldRenames = map[string]string{
+	"liveAdPreRoll": "live_ad_preroll",
+}
+
+// leadingFieldPattern matches messages starting with the path of the rejected
+// field, such as `liveAdReplacement.gapFiller.id must be a number`.
+var leadingFieldPattern = regexp.MustCompile(`^([a-z][A-Za-z0-9]*(?:\.[A-Za-z0-9]+)*):?\s+(.+)$`)
+
+// parseValidationErrors extracts the validation messages from the JSON body
+// embedded in an API error. It returns nil when err carries no JSON body.
+func parseValidationErrors(err error) []apiFieldError {
+	msg := err.Error()
+	start := strings.Index(msg, "{")
+	if start < 0 {
+		return nil
+	}
+
+	var body struct {
+		Message json.RawMessage `json:"message"`
+		Errors  []struct {
+			Field       string            `json:"field"`
+			Property    string            `json:"property"`
+			Path        string            `json:"path"`
+			Message     string            `json:"message"`
+			Constraints map[string]string `json:"constraints"`
+		} `json:"errors"`
+	}
+	if json.NewDecoder(strings.NewReader(msg[start:])).Decode(&body) != nil {
+		return nil
+	}
+
+	var out []apiFieldError
+	for _, e := range body.Errors {
+		field := firstNonEmpty(e.Field, e.Property, e.Path)
+		if e.Message != "" {
+			out = append(out, apiFieldError{Field: field, Message: e.Message})
+		}
+		keys := make([]string, 0, len(e.Constraints))
+		for k := range e.Constraints {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			out = append(out, apiFieldError{Field: field, Message: e.Constraints[k]})
+		}
+	}
+
+	// The message is either a single string or a list of strings, each one
+	// usually starting with the path of the rejected field.
+	var messages []string
+	var single string
+	if json.Unmarshal(body.Message, &single) == nil && single != "" {
+		messages = []string{single}
+	} else {
+		_ = json.Unmarshal(body.Message, &messages)
+	}
+	for _, m := range messages {
+		fe := apiFieldError{Message: m}
+		if match := leadingFieldPattern.FindStringSubmatch(m); match != nil {
+			fe.Field = match[1]
+		}
+		out = append(out, fe)
+	}
+
+	return out
+}
+
+// apiFieldToPath converts an API field path into the matching attribute path
+// of s. Fields pointing at a nested object referenced by ID (gap filler, ad
+// server, ...) map to its `id` attribute.
+func apiFieldToPath(ctx context.Context, s schema.Schema, field string) (path.Path, bool) {
+	if field == "" {
+		return path.Empty(), false
+	}
+
+	p := path.Empty()
+	for _, segment := range strings.Split(field, ".") {
+		if index, err := strconv.Atoi(segment); err == nil {
+			p = p.AtListIndex(index)
+			continue
+		}
+		name, ok := apiFieldRenames[segment]
+		if !ok {
+			name = toSnakeCase(segment)
+		}
+		p = p.AtName(name)
+	}
+
+	attribute, diags := s.AttributeAtPath(ctx, p)
+	if diags.HasError() {
+		return path.Empty(), false
+	}
+	if nested, ok := attribute.(schema.SingleNestedAttribute); ok {
+		if _, ok := nested.Attributes["id"]; ok {
+			p = p.AtName("id")
+		}
+	}
+	return p, true
+}
+
+// addAPIErrorDiagnostics reports err on diags. Validation messages naming a
+// field of the resource become attribute errors so that they point at the
+// offending line of configuration; everything else is reported once at the
+// resource level, prefixed by detail.
+func addAPIErrorDiagnostics(ctx context.Context, diags *diag.Diagnostics, r resource.Resource, summary, detail string, err error) {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	var mapped diag.Diagnostics
+	var unmapped []string
+	for _, fe := range parseValidationErrors(err) {
+		if p, ok := apiFieldToPath(ctx, schemaResp.Schema, fe.Field); ok {
+			mapped.AddAttributeError(p, summary, "The Broadpeak API rejected this value: "+fe.Message)
+		} else {
+			unmapped = append(unmapped, fe.Message)
+		}
+	}
+	if !mapped.HasError() {
+		diags.AddError(summary, fmt.Sprintf("%s: %s", detail, err))
+		return
+	}
+	diags.Append(mapped...)
+	for _, m := range unmapped {
+		diags.AddError(summary, fmt.Sprintf("%s: %s", detail, m))
+	}
+}
+
+// toSnakeCase converts a camelCase API field name into its snake_case form.
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []apiFieldError
+	}{
+		{
+			name: "no body",
+			err:  errors.New("connection refused"),
+			want: nil,
+		},
+		{
+			name: "message list",
+			err:  errors.New(`error: 400, body: {"statusCode":400,"message":["liveAdReplacement.gapFiller must reference a slate","name should not be empty"],"error":"Bad Request"}`),
+			want: []apiFieldError{
+				{Field: "liveAdReplacement.gapFiller", Message: "liveAdReplacement.gapFiller must reference a slate"},
+				{Field: "name", Message: "name should not be empty"},
+			},
+		},
+		{
+			name: "single message without field",
+			err:  errors.New(`error: 400, body: {"message":"Quota exceeded"}`),
+			want: []apiFieldError{{Message: "Quota exceeded"}},
+		},
+		{
+			name: "errors list",
+			err:  errors.New(`400 Bad Request: {"errors":[{"property":"queryParameters.1.type","constraints":{"isEnum":"type must be one of custom, forward"}}]}`),
+			want: []apiFieldError{{Field: "queryParameters.1.type", Message: "type must be one of custom, forward"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseValidationErrors(tt.err))
+		})
+	}
+}
+
+func TestAddAPIErrorDiagnostics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ad insertion fields", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := errors.New(`error: 400, body: {"message":["liveAdReplacement.gapFiller must reference a slate","liveAdPreRoll.maxDuration must be a positive number","unknownField is invalid"]}`)
+		addAPIErrorDiagnostics(ctx, &diags, NewServiceAdInsertionResource(), "Error creating Ad-Insertion", "Could not create Ad-Insertion", err)
+
+		require.Len(t, diags, 3)
+		require.Equal(t, path.Root("live_ad_replacement").AtName("gap_filler").AtName("id"), diags[0].(diag.DiagnosticWithPath).Path())
+		require.Equal(t, path.Root("live_ad_preroll").AtName("max_duration"), diags[1].(diag.DiagnosticWithPath).Path())
+		require.Equal(t, "Could not create Ad-Insertion: unknownField is invalid", diags[2].Detail())
+	})
+
+	t.Run("ad server list element", func(t *testing.T) {
+		var diags diag.Diagnostics
+		err := errors.New(`{"errors":[{"field":"queryParameters.1.type","message":"must be one of custom, forward"}]}`)
+		addAPIErrorDiagnostics(ctx, &diags, NewSourceAdServerResource(), "Error Creating Ad-Server", "Could not create Ad-Server", err)
+
+		require.Len(t, diags, 1)
+		require.Equal(t, path.Root("query_parameters").AtListIndex(1).AtName("type"), diags[0].(diag.DiagnosticWithPath).Path())
+	})
+
+	t.Run("unstructured error", func(t *testing.T) {
+		var diags diag.Diagnostics
+		addAPIErrorDiagnostics(ctx, &diags, NewSourceAdServerResource(), "Error Creating Ad-Server", "Could not create Ad-Server", errors.New("502 Bad Gateway"))
+
+		require.Len(t, diags, 1)
+		_, hasPath := diags[0].(diag.DiagnosticWithPath)
+		require.False(t, hasPath)
+		require.Equal(t, "Could not create Ad-Server: 502 Bad Gateway", diags[0].Detail())
+	})
+}
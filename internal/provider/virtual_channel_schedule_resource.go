@@ -0,0 +1,711 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &virtualChannelScheduleResource{}
+	_ resource.ResourceWithConfigure      = &virtualChannelScheduleResource{}
+	_ resource.ResourceWithValidateConfig = &virtualChannelScheduleResource{}
+	_ resource.ResourceWithModifyPlan     = &virtualChannelScheduleResource{}
+)
+
+// NewVirtualChannelScheduleResource is a helper function to simplify the provider implementation.
+func NewVirtualChannelScheduleResource() resource.Resource {
+	return &virtualChannelScheduleResource{}
+}
+
+// virtualChannelScheduleResource manages all the slots of a virtual channel
+// that start within a time window, as a single resource.
+type virtualChannelScheduleResource struct {
+	client apiClient
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *virtualChannelScheduleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected a bpkio API client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *virtualChannelScheduleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_virtual_channel_schedule"
+}
+
+// scheduleSlotAttrTypes are the attribute types of an element of `slots`.
+var scheduleSlotAttrTypes = map[string]attr.Type{
+	"id":             types.Int64Type,
+	"name":           types.StringType,
+	"start_time":     types.StringType,
+	"end_time":       types.StringType,
+	"duration":       types.Int64Type,
+	"replacement_id": types.Int64Type,
+	"category_id":    types.Int64Type,
+}
+
+// Schema defines the schema for the resource.
+func (r *virtualChannelScheduleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the slots of a virtual channel service that start within a time window. " +
+			"On apply, the slots of the window are compared with the Broadpeak API and only the slots that differ are created, updated or deleted. " +
+			"Slots starting outside the window are left alone.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the schedule, which is the ID of its virtual channel service.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "ID of the virtual channel service. Changing it forces a new schedule.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"window_start": schema.StringAttribute{
+				Required:    true,
+				Description: "Start of the window managed by the schedule, as an RFC3339 timestamp.",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"window_end": schema.StringAttribute{
+				Required:    true,
+				Description: "End of the window managed by the schedule, as an RFC3339 timestamp. Slots starting at or after it are left alone.",
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"slots": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "Slots of the window. Slots are matched with the ones of the API by start time, and must start within the window and not overlap.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "ID of the slot.",
+						},
+						"name": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Name of the slot.",
+							Default:     stringdefault.StaticString(""),
+						},
+						"start_time": schema.StringAttribute{
+							Required:    true,
+							Description: "Start of the slot, as an RFC3339 timestamp.",
+							Validators: []validator.String{
+								rfc3339Validator{},
+							},
+						},
+						"end_time": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "End of the slot, as an RFC3339 timestamp. Exactly one of `end_time` and `duration` must be set.",
+							Validators: []validator.String{
+								rfc3339Validator{},
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("duration")),
+							},
+						},
+						"duration": schema.Int64Attribute{
+							Optional:    true,
+							Computed:    true,
+							Description: "Duration of the slot (in seconds). Exactly one of `end_time` and `duration` must be set.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"replacement_id": schema.Int64Attribute{
+							Required:    true,
+							Description: "ID of the live, asset or slate source played during the slot.",
+						},
+						"category_id": schema.Int64Attribute{
+							Optional:    true,
+							Description: "ID of the category of the slot.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// span returns the start and end of s, and false when they are not known
+// yet.
+func (s scheduleSlotModel) span() (time.Time, time.Time, bool) {
+	if s.StartTime.IsNull() || s.StartTime.IsUnknown() {
+		return time.Time{}, time.Time{}, false
+	}
+	start, err := time.Parse(time.RFC3339, s.StartTime.ValueString())
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	if !s.EndTime.IsNull() && !s.EndTime.IsUnknown() {
+		end, err := time.Parse(time.RFC3339, s.EndTime.ValueString())
+		return start, end, err == nil
+	}
+	if !s.Duration.IsNull() && !s.Duration.IsUnknown() {
+		return start, start.Add(time.Duration(s.Duration.ValueInt64()) * time.Second), true
+	}
+	return start, time.Time{}, false
+}
+
+// ValidateConfig checks that the slots fit in the window and do not overlap.
+func (r *virtualChannelScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config virtualChannelScheduleModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Slots.IsNull() || config.Slots.IsUnknown() {
+		return
+	}
+
+	var slots []scheduleSlotModel
+	resp.Diagnostics.Append(config.Slots.ElementsAs(ctx, &slots, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	windowStart, errStart := time.Parse(time.RFC3339, config.WindowStart.ValueString())
+	windowEnd, errEnd := time.Parse(time.RFC3339, config.WindowEnd.ValueString())
+	windowKnown := errStart == nil && errEnd == nil
+	if windowKnown && !windowEnd.After(windowStart) {
+		resp.Diagnostics.AddAttributeError(path.Root("window_end"), "Invalid Window", "window_end must be after window_start.")
+		return
+	}
+
+	resp.Diagnostics.Append(validateScheduleSlots(slots, windowStart, windowEnd, windowKnown)...)
+}
+
+// validateScheduleSlots reports the slots that start outside the window, end
+// before they start or overlap another slot. Slots whose times are not known
+// yet are skipped.
+func validateScheduleSlots(slots []scheduleSlotModel, windowStart, windowEnd time.Time, windowKnown bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	type span struct {
+		index      int
+		start, end time.Time
+	}
+	var spans []span
+	for i, slot := range slots {
+		start, end, ok := slot.span()
+		if !ok {
+			continue
+		}
+		slotPath := path.Root("slots").AtListIndex(i)
+		if windowKnown && (start.Before(windowStart) || !start.Before(windowEnd)) {
+			diags.AddAttributeError(slotPath.AtName("start_time"), "Slot Outside Window",
+				fmt.Sprintf("The slot starting at %s does not start within the window of the schedule.", slot.StartTime.ValueString()))
+		}
+		if !end.After(start) {
+			diags.AddAttributeError(slotPath.AtName("end_time"), "Invalid Slot",
+				fmt.Sprintf("The slot starting at %s must end after it starts.", slot.StartTime.ValueString()))
+			continue
+		}
+		spans = append(spans, span{index: i, start: start, end: end})
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	for i := 1; i < len(spans); i++ {
+		prev, cur := spans[i-1], spans[i]
+		if cur.start.Before(prev.end) {
+			diags.AddAttributeError(path.Root("slots").AtListIndex(cur.index), "Overlapping Slots",
+				fmt.Sprintf("The slot starting at %s overlaps the slot starting at %s (slots[%d]).",
+					slots[cur.index].StartTime.ValueString(), slots[prev.index].StartTime.ValueString(), prev.index))
+		}
+	}
+	return diags
+}
+
+// ModifyPlan fills in the end time or duration that is derived from the
+// other, keeps the IDs of the slots that already exist and reports a
+// summary of the slot changes.
+func (r *virtualChannelScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan virtualChannelScheduleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Slots.IsUnknown() {
+		return
+	}
+	var slots []scheduleSlotModel
+	resp.Diagnostics.Append(plan.Slots.ElementsAs(ctx, &slots, false)...)
+
+	var prior []scheduleSlotModel
+	if !req.State.Raw.IsNull() {
+		var state virtualChannelScheduleModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(state.Slots.ElementsAs(ctx, &prior, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorByStart := map[int64]scheduleSlotModel{}
+	for _, slot := range prior {
+		if start, _, ok := slot.span(); ok {
+			priorByStart[start.UnixNano()] = slot
+		}
+	}
+	for i := range slots {
+		slot := &slots[i]
+		start, end, ok := slot.span()
+		if !ok {
+			continue
+		}
+		match, matched := priorByStart[start.UnixNano()]
+		if slot.EndTime.IsUnknown() {
+			slot.EndTime = types.StringValue(end.Format(time.RFC3339))
+			if matched {
+				slot.EndTime = sameInstant(match.EndTime, slot.EndTime.ValueString())
+			}
+		}
+		if slot.Duration.IsUnknown() {
+			slot.Duration = types.Int64Value(int64(end.Sub(start) / time.Second))
+		}
+		if slot.ID.IsUnknown() && matched {
+			slot.ID = match.ID
+		}
+	}
+
+	planned, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: scheduleSlotAttrTypes}, slots)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("slots"), planned)...)
+
+	// Prior slots that the planned window no longer covers are left alone.
+	windowStart, errStart := time.Parse(time.RFC3339, plan.WindowStart.ValueString())
+	windowEnd, errEnd := time.Parse(time.RFC3339, plan.WindowEnd.ValueString())
+	current := make([]apiSlot, 0, len(prior))
+	for _, slot := range prior {
+		start, _, _ := slot.span()
+		if errStart == nil && errEnd == nil && (start.Before(windowStart) || !start.Before(windowEnd)) {
+			continue
+		}
+		current = append(current, slot.toAPI())
+	}
+	if ops := diffSchedule(current, slots); len(ops) > 0 {
+		lines := make([]string, 0, len(ops))
+		for _, op := range ops {
+			lines = append(lines, op.String())
+		}
+		resp.Diagnostics.AddWarning(
+			"Virtual Channel Schedule Changes",
+			fmt.Sprintf("Applying this plan changes %d slot(s) of virtual channel service %d:\n%s",
+				len(ops), plan.ServiceID.ValueInt64(), strings.Join(lines, "\n")),
+		)
+	}
+}
+
+// expand builds the API input of the slot. Only the end time is sent when it
+// is known, since the duration is then derived from it.
+func (s scheduleSlotModel) expand() apiSlotInput {
+	input := apiSlotInput{
+		Name:        s.Name.ValueString(),
+		StartTime:   s.StartTime.ValueString(),
+		Replacement: apiRef{Id: uint(s.ReplacementID.ValueInt64())},
+	}
+	if !s.EndTime.IsNull() && !s.EndTime.IsUnknown() {
+		input.EndTime = s.EndTime.ValueString()
+	} else {
+		input.Duration = uint(s.Duration.ValueInt64())
+	}
+	if !s.CategoryID.IsNull() && !s.CategoryID.IsUnknown() {
+		input.Category = &apiRef{Id: uint(s.CategoryID.ValueInt64())}
+	}
+	return input
+}
+
+// toAPI converts a slot of the state back into the API slot it was read
+// from.
+func (s scheduleSlotModel) toAPI() apiSlot {
+	slot := apiSlot{
+		Id:          uint(s.ID.ValueInt64()),
+		Name:        s.Name.ValueString(),
+		StartTime:   s.StartTime.ValueString(),
+		EndTime:     s.EndTime.ValueString(),
+		Duration:    uint(s.Duration.ValueInt64()),
+		Replacement: apiSourceRef{Id: uint(s.ReplacementID.ValueInt64())},
+	}
+	if !s.CategoryID.IsNull() {
+		slot.Category = &apiCategoryRef{Id: uint(s.CategoryID.ValueInt64())}
+	}
+	return slot
+}
+
+// scheduleOp is a change to one slot of a schedule window.
+type scheduleOp struct {
+	action string // "create", "update" or "delete"
+	input  apiSlotInput
+	slot   apiSlot // the existing slot, for updates and deletes
+}
+
+func (op scheduleOp) String() string {
+	switch op.action {
+	case "create":
+		return fmt.Sprintf("  + %s %q (replacement %d)", op.input.StartTime, op.input.Name, op.input.Replacement.Id)
+	case "update":
+		return fmt.Sprintf("  ~ %s %q (slot %d)", op.input.StartTime, op.input.Name, op.slot.Id)
+	default:
+		return fmt.Sprintf("  - %s %q (slot %d)", op.slot.StartTime, op.slot.Name, op.slot.Id)
+	}
+}
+
+// slotMatches reports whether the existing slot already has the values of
+// input. Both have the same start time.
+func slotMatches(slot apiSlot, input apiSlotInput) bool {
+	if slot.Name != input.Name || slot.Replacement.Id != input.Replacement.Id {
+		return false
+	}
+	var category uint
+	if slot.Category != nil {
+		category = slot.Category.Id
+	}
+	if input.Category == nil && category != 0 || input.Category != nil && input.Category.Id != category {
+		return false
+	}
+	if input.EndTime == "" {
+		return slot.Duration == input.Duration
+	}
+	want, err1 := time.Parse(time.RFC3339, input.EndTime)
+	got, err2 := time.Parse(time.RFC3339, slot.EndTime)
+	return err1 == nil && err2 == nil && want.Equal(got)
+}
+
+// diffSchedule returns the changes that turn the current slots of a window
+// into the desired ones, matching them by start time. Deletions come first so
+// that the time they free can be reused by the other changes.
+func diffSchedule(current []apiSlot, desired []scheduleSlotModel) []scheduleOp {
+	byStart := make(map[int64]apiSlot, len(current))
+	for _, slot := range current {
+		if start, err := time.Parse(time.RFC3339, slot.StartTime); err == nil {
+			byStart[start.UnixNano()] = slot
+		}
+	}
+
+	var deletes, changes []scheduleOp
+	kept := map[uint]bool{}
+	for _, slot := range desired {
+		input := slot.expand()
+		start, err := time.Parse(time.RFC3339, input.StartTime)
+		existing, ok := byStart[start.UnixNano()]
+		switch {
+		case err != nil || !ok:
+			changes = append(changes, scheduleOp{action: "create", input: input})
+		case !slotMatches(existing, input):
+			kept[existing.Id] = true
+			changes = append(changes, scheduleOp{action: "update", input: input, slot: existing})
+		default:
+			kept[existing.Id] = true
+		}
+	}
+	for _, slot := range current {
+		if !kept[slot.Id] {
+			deletes = append(deletes, scheduleOp{action: "delete", slot: slot})
+		}
+	}
+	return append(deletes, changes...)
+}
+
+// listWindow returns the slots of the service that start within the window
+// of m.
+func (r *virtualChannelScheduleResource) listWindow(ctx context.Context, m virtualChannelScheduleModel) ([]apiSlot, error) {
+	serviceID := uint(m.ServiceID.ValueInt64())
+	from, to := m.WindowStart.ValueString(), m.WindowEnd.ValueString()
+	windowStart, _ := time.Parse(time.RFC3339, from)
+	windowEnd, _ := time.Parse(time.RFC3339, to)
+
+	slots, _, err := listAll(func(offset, limit int) ([]apiSlot, error) {
+		return r.client.ListVirtualChannelSlots(ctx, serviceID, from, to, offset, limit)
+	}, 0, func(slot apiSlot) bool {
+		start, err := time.Parse(time.RFC3339, slot.StartTime)
+		return err == nil && !start.Before(windowStart) && start.Before(windowEnd)
+	})
+	return slots, err
+}
+
+// flattenScheduleSlots converts the API slots of a window into the `slots`
+// of the state. Slots are listed in the order of order, which holds the
+// planned or prior slots, followed by the others sorted by start time.
+func flattenScheduleSlots(ctx context.Context, slots []apiSlot, order []scheduleSlotModel) (types.List, diag.Diagnostics) {
+	byStart := make(map[int64]apiSlot, len(slots))
+	for _, slot := range slots {
+		start, _ := time.Parse(time.RFC3339, slot.StartTime)
+		byStart[start.UnixNano()] = slot
+	}
+
+	out := make([]scheduleSlotModel, 0, len(slots))
+	add := func(slot apiSlot, prior scheduleSlotModel) {
+		model := scheduleSlotModel{
+			ID:            types.Int64Value(int64(slot.Id)),
+			Name:          types.StringValue(slot.Name),
+			StartTime:     sameInstant(prior.StartTime, slot.StartTime),
+			EndTime:       sameInstant(prior.EndTime, slot.EndTime),
+			Duration:      types.Int64Value(int64(slot.Duration)),
+			ReplacementID: types.Int64Value(int64(slot.Replacement.Id)),
+			CategoryID:    types.Int64Null(),
+		}
+		if slot.Category != nil && slot.Category.Id != 0 {
+			model.CategoryID = types.Int64Value(int64(slot.Category.Id))
+		}
+		out = append(out, model)
+	}
+
+	for _, prior := range order {
+		start, _, ok := prior.span()
+		if !ok {
+			continue
+		}
+		if slot, found := byStart[start.UnixNano()]; found {
+			add(slot, prior)
+			delete(byStart, start.UnixNano())
+		}
+	}
+	rest := make([]apiSlot, 0, len(byStart))
+	for _, slot := range byStart {
+		rest = append(rest, slot)
+	}
+	sort.Slice(rest, func(i, j int) bool { return rest[i].StartTime < rest[j].StartTime })
+	for _, slot := range rest {
+		add(slot, scheduleSlotModel{StartTime: types.StringNull(), EndTime: types.StringNull()})
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: scheduleSlotAttrTypes}, out)
+}
+
+// apply reconciles the window of plan with the API and returns the
+// resulting state.
+func (r *virtualChannelScheduleResource) apply(ctx context.Context, plan virtualChannelScheduleModel, diags *diag.Diagnostics) (virtualChannelScheduleModel, bool) {
+	var desired []scheduleSlotModel
+	diags.Append(plan.Slots.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return plan, false
+	}
+
+	serviceID := uint(plan.ServiceID.ValueInt64())
+	current, err := r.listWindow(ctx, plan)
+	if err != nil {
+		diags.AddError(
+			"Unable to List Slots",
+			fmt.Sprintf("Could not list the slots of virtual channel service ID %d: %s", serviceID, err),
+		)
+		return plan, false
+	}
+
+	results := make(map[uint]apiSlot, len(current))
+	for _, slot := range current {
+		results[slot.Id] = slot
+	}
+	for _, op := range diffSchedule(current, desired) {
+		tflog.Debug(ctx, "Applying schedule change", map[string]interface{}{"service_id": serviceID, "change": strings.TrimSpace(op.String())})
+
+		switch op.action {
+		case "delete":
+			err = r.client.DeleteVirtualChannelSlot(ctx, serviceID, op.slot.Id)
+			if err != nil && !isNotFound(err) && !slotEnded(op.slot, time.Now()) {
+				diags.AddError("Error Deleting Slot", fmt.Sprintf("Could not delete slot ID %d of virtual channel service ID %d: %s", op.slot.Id, serviceID, err))
+				return plan, false
+			}
+			delete(results, op.slot.Id)
+		case "update":
+			slot, err := r.client.UpdateVirtualChannelSlot(ctx, serviceID, op.slot.Id, op.input)
+			if err != nil {
+				diags.AddError("Error updating Slot", fmt.Sprintf("Could not update slot ID %d of virtual channel service ID %d: %s", op.slot.Id, serviceID, err))
+				return plan, false
+			}
+			results[slot.Id] = slot
+		case "create":
+			slot, err := r.client.CreateVirtualChannelSlot(ctx, serviceID, op.input)
+			if err != nil {
+				diags.AddError("Error creating Slot", fmt.Sprintf("Could not create the slot starting at %s on virtual channel service ID %d: %s", op.input.StartTime, serviceID, err))
+				return plan, false
+			}
+			results[slot.Id] = slot
+		}
+	}
+
+	slots := make([]apiSlot, 0, len(results))
+	for _, slot := range results {
+		slots = append(slots, slot)
+	}
+	list, d := flattenScheduleSlots(ctx, slots, desired)
+	diags.Append(d...)
+
+	state := plan
+	state.ID = types.StringValue(strconv.FormatInt(plan.ServiceID.ValueInt64(), 10))
+	state.Slots = list
+	return state, !diags.HasError()
+}
+
+// slotEnded reports whether slot is over at now.
+func slotEnded(slot apiSlot, now time.Time) bool {
+	end, err := time.Parse(time.RFC3339, slot.EndTime)
+	return err == nil && !end.After(now)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *virtualChannelScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan virtualChannelScheduleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, ok := r.apply(ctx, plan, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Read refreshes the Terraform state with the slots of the window.
+func (r *virtualChannelScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state virtualChannelScheduleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slots, err := r.listWindow(ctx, state)
+	if isNotFound(err) {
+		tflog.Warn(ctx, "Virtual channel service not found, removing schedule from state", map[string]interface{}{"service_id": state.ServiceID.ValueInt64()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Virtual Channel Schedule",
+			fmt.Sprintf("Could not list the slots of virtual channel service ID %d: %s", state.ServiceID.ValueInt64(), err),
+		)
+		return
+	}
+
+	var prior []scheduleSlotModel
+	resp.Diagnostics.Append(state.Slots.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var diags diag.Diagnostics
+	state.Slots, diags = flattenScheduleSlots(ctx, slots, prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update reconciles the window with the planned slots.
+func (r *virtualChannelScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan virtualChannelScheduleModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, ok := r.apply(ctx, plan, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Delete deletes the slots of the window. Slots that have ended are left to
+// the API, which may refuse to delete them.
+func (r *virtualChannelScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state virtualChannelScheduleModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID := uint(state.ServiceID.ValueInt64())
+	slots, err := r.listWindow(ctx, state)
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Virtual Channel Schedule",
+			fmt.Sprintf("Could not list the slots of virtual channel service ID %d: %s", serviceID, err),
+		)
+		return
+	}
+
+	for _, slot := range slots {
+		err := r.client.DeleteVirtualChannelSlot(ctx, serviceID, slot.Id)
+		if err != nil && !isNotFound(err) && !slotEnded(slot, time.Now()) {
+			resp.Diagnostics.AddError(
+				"Error Deleting Virtual Channel Schedule",
+				fmt.Sprintf("Could not delete slot ID %d of virtual channel service ID %d: %s", slot.Id, serviceID, err),
+			)
+			return
+		}
+	}
+}
+
+// virtualChannelScheduleModel maps the schema of the schedule resource.
+type virtualChannelScheduleModel struct {
+	ID          types.String `tfsdk:"id"`
+	ServiceID   types.Int64  `tfsdk:"service_id"`
+	WindowStart types.String `tfsdk:"window_start"`
+	WindowEnd   types.String `tfsdk:"window_end"`
+	Slots       types.List   `tfsdk:"slots"`
+}
+
+type scheduleSlotModel struct {
+	ID            types.Int64  `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	StartTime     types.String `tfsdk:"start_time"`
+	EndTime       types.String `tfsdk:"end_time"`
+	Duration      types.Int64  `tfsdk:"duration"`
+	ReplacementID types.Int64  `tfsdk:"replacement_id"`
+	CategoryID    types.Int64  `tfsdk:"category_id"`
+}
@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func scheduleSlot(start, end, name string, replacement int64) scheduleSlotModel {
+	return scheduleSlotModel{
+		ID:            types.Int64Unknown(),
+		Name:          types.StringValue(name),
+		StartTime:     types.StringValue(start),
+		EndTime:       types.StringValue(end),
+		Duration:      types.Int64Unknown(),
+		ReplacementID: types.Int64Value(replacement),
+		CategoryID:    types.Int64Null(),
+	}
+}
+
+func TestDiffSchedule(t *testing.T) {
+	current := []apiSlot{
+		{Id: 1, Name: "news", StartTime: "2025-06-01T18:00:00Z", EndTime: "2025-06-01T19:00:00Z", Duration: 3600, Replacement: apiSourceRef{Id: 10}},
+		{Id: 2, Name: "movie", StartTime: "2025-06-01T19:00:00Z", EndTime: "2025-06-01T21:00:00Z", Duration: 7200, Replacement: apiSourceRef{Id: 11}},
+		{Id: 3, Name: "late show", StartTime: "2025-06-01T21:00:00Z", EndTime: "2025-06-01T22:00:00Z", Duration: 3600, Replacement: apiSourceRef{Id: 12}},
+	}
+	desired := []scheduleSlotModel{
+		// Same instant, spelled with an offset.
+		scheduleSlot("2025-06-01T20:00:00+02:00", "2025-06-01T21:00:00+02:00", "news", 10),
+		scheduleSlot("2025-06-01T19:00:00Z", "2025-06-01T21:30:00Z", "movie", 11),
+		scheduleSlot("2025-06-01T21:30:00Z", "2025-06-01T22:00:00Z", "short", 12),
+	}
+
+	ops := diffSchedule(current, desired)
+	require.Len(t, ops, 3)
+	require.Equal(t, "delete", ops[0].action, "deletions come first")
+	require.Equal(t, uint(3), ops[0].slot.Id)
+	require.Equal(t, "update", ops[1].action)
+	require.Equal(t, uint(2), ops[1].slot.Id)
+	require.Equal(t, "2025-06-01T21:30:00Z", ops[1].input.EndTime)
+	require.Equal(t, "create", ops[2].action)
+	require.Equal(t, "short", ops[2].input.Name)
+
+	require.Empty(t, diffSchedule(current, []scheduleSlotModel{
+		scheduleSlot("2025-06-01T18:00:00Z", "2025-06-01T19:00:00Z", "news", 10),
+		scheduleSlot("2025-06-01T19:00:00Z", "2025-06-01T21:00:00Z", "movie", 11),
+		scheduleSlot("2025-06-01T21:00:00Z", "2025-06-01T22:00:00Z", "late show", 12),
+	}))
+
+	withCategory := scheduleSlot("2025-06-01T18:00:00Z", "2025-06-01T19:00:00Z", "news", 10)
+	withCategory.CategoryID = types.Int64Value(4)
+	ops = diffSchedule(current[:1], []scheduleSlotModel{withCategory})
+	require.Len(t, ops, 1)
+	require.Equal(t, "update", ops[0].action)
+	require.Contains(t, ops[0].String(), `~ 2025-06-01T18:00:00Z "news" (slot 1)`)
+}
+
+func TestValidateScheduleSlots(t *testing.T) {
+	windowStart := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	windowEnd := windowStart.Add(24 * time.Hour)
+
+	valid := []scheduleSlotModel{
+		scheduleSlot("2025-06-01T19:00:00Z", "2025-06-01T21:00:00Z", "movie", 1),
+		scheduleSlot("2025-06-01T18:00:00Z", "2025-06-01T19:00:00Z", "news", 1),
+	}
+	require.False(t, validateScheduleSlots(valid, windowStart, windowEnd, true).HasError())
+
+	byDuration := scheduleSlot("2025-06-01T18:30:00Z", "", "overlap", 1)
+	byDuration.EndTime = types.StringNull()
+	byDuration.Duration = types.Int64Value(600)
+	diags := validateScheduleSlots(append(valid, byDuration), windowStart, windowEnd, true)
+	require.Len(t, diags, 1)
+	require.Equal(t, "Overlapping Slots", diags[0].Summary())
+	require.Contains(t, diags[0].Detail(), "2025-06-01T18:30:00Z overlaps the slot starting at 2025-06-01T18:00:00Z (slots[1])")
+
+	outside := scheduleSlot("2025-06-02T00:00:00Z", "2025-06-02T01:00:00Z", "tomorrow", 1)
+	diags = validateScheduleSlots([]scheduleSlotModel{outside}, windowStart, windowEnd, true)
+	require.Len(t, diags, 1)
+	require.Equal(t, "Slot Outside Window", diags[0].Summary())
+	require.False(t, validateScheduleSlots([]scheduleSlotModel{outside}, windowStart, windowEnd, false).HasError())
+
+	backwards := scheduleSlot("2025-06-01T19:00:00Z", "2025-06-01T18:00:00Z", "backwards", 1)
+	diags = validateScheduleSlots([]scheduleSlotModel{backwards}, windowStart, windowEnd, true)
+	require.Len(t, diags, 1)
+	require.Equal(t, "Invalid Slot", diags[0].Summary())
+
+	unknown := scheduleSlot("2025-06-01T18:30:00Z", "", "unknown", 1)
+	unknown.EndTime = types.StringUnknown()
+	require.False(t, validateScheduleSlots(append(valid, unknown), windowStart, windowEnd, true).HasError())
+}
+
+func TestFlattenScheduleSlots(t *testing.T) {
+	slots := []apiSlot{
+		{Id: 3, StartTime: "2025-06-01T21:00:00Z", EndTime: "2025-06-01T22:00:00Z", Duration: 3600, Replacement: apiSourceRef{Id: 12}},
+		{Id: 1, StartTime: "2025-06-01T18:00:00Z", EndTime: "2025-06-01T19:00:00Z", Duration: 3600, Replacement: apiSourceRef{Id: 10}, Category: &apiCategoryRef{Id: 4}},
+		{Id: 2, StartTime: "2025-06-01T19:00:00Z", EndTime: "2025-06-01T21:00:00Z", Duration: 7200, Replacement: apiSourceRef{Id: 11}},
+	}
+	order := []scheduleSlotModel{
+		scheduleSlot("2025-06-01T21:00:00+02:00", "2025-06-01T23:00:00+02:00", "", 11),
+		scheduleSlot("2025-06-01T10:00:00Z", "2025-06-01T11:00:00Z", "gone", 11),
+	}
+
+	list, diags := flattenScheduleSlots(context.Background(), slots, order)
+	require.False(t, diags.HasError())
+	var got []scheduleSlotModel
+	require.False(t, list.ElementsAs(context.Background(), &got, false).HasError())
+
+	require.Len(t, got, 3)
+	require.Equal(t, int64(2), got[0].ID.ValueInt64(), "slots of order come first")
+	require.Equal(t, "2025-06-01T21:00:00+02:00", got[0].StartTime.ValueString(), "the spelling of order is kept")
+	require.Equal(t, int64(1), got[1].ID.ValueInt64(), "other slots follow by start time")
+	require.Equal(t, int64(4), got[1].CategoryID.ValueInt64())
+	require.Equal(t, int64(3), got[2].ID.ValueInt64())
+	require.True(t, got[2].CategoryID.IsNull())
+}
+
+func TestAccVirtualChannelSchedule_Basic(t *testing.T) {
+	apiKey := os.Getenv("BPKIO_API_KEY")
+	resourceName := "bpkio_virtual_channel_schedule.test"
+	name := "tf-acc-test-schedule-" + randomSuffix()
+	day := time.Now().Add(48 * time.Hour).UTC().Truncate(24 * time.Hour)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVirtualChannelConfig(apiKey, name, `[]`) + testAccVirtualChannelScheduleConfig(day, map[int]string{18: "news", 19: "movie"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "slots.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "slots.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "slots.1.duration", "3600"),
+				),
+			},
+			{
+				Config: testAccServiceVirtualChannelConfig(apiKey, name, `[]`) + testAccVirtualChannelScheduleConfig(day, map[int]string{19: "movie", 20: "late show"}),
+				Check:  resource.TestCheckResourceAttr(resourceName, "slots.#", "2"),
+			},
+		},
+	})
+}
+
+// testAccVirtualChannelScheduleConfig schedules one hour long slots of the
+// slate at the given hours of day.
+func testAccVirtualChannelScheduleConfig(day time.Time, slots map[int]string) string {
+	var b strings.Builder
+	for hour := 0; hour < 24; hour++ {
+		name, ok := slots[hour]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, `
+    {
+      name           = %q
+      start_time     = %q
+      duration       = 3600
+      replacement_id = bpkio_source_slate.slate.id
+    },`, name, day.Add(time.Duration(hour)*time.Hour).Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf(`
+resource "bpkio_virtual_channel_schedule" "test" {
+  service_id   = bpkio_service_virtual_channel.test.id
+  window_start = %q
+  window_end   = %q
+
+  slots = [%s
+  ]
+}
+`, day.Format(time.RFC3339), day.Add(24*time.Hour).Format(time.RFC3339), b.String())
+}
+
+func TestVirtualChannelScheduleResource_Lifecycle(t *testing.T) {
+	testUnitPreCheck(t)
+
+	client := newFakeClient()
+	resourceName := "bpkio_virtual_channel_schedule.test"
+	day := time.Now().Add(48 * time.Hour).UTC().Truncate(24 * time.Hour)
+	service := testAccServiceVirtualChannelConfig("fake", "vc", `[]`)
+
+	var movieID string
+	var outside apiSlot
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProviderFactories(client),
+		Steps: []resource.TestStep{
+			{
+				Config: service + testAccVirtualChannelScheduleConfig(day, map[int]string{18: "news", 19: "movie", 20: "late show"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "slots.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "slots.0.end_time", day.Add(19*time.Hour).Format(time.RFC3339)),
+					resource.TestCheckResourceAttr(resourceName, "slots.1.name", "movie"),
+					func(s *terraform.State) error {
+						movieID = s.RootModule().Resources[resourceName].Primary.Attributes["slots.1.id"]
+						return nil
+					},
+				),
+			},
+			{
+				// A slot of the next day is not part of the window.
+				PreConfig: func() {
+					for id := range client.virtualChannels {
+						var err error
+						outside, err = client.CreateVirtualChannelSlot(context.Background(), id, apiSlotInput{
+							StartTime:   day.Add(30 * time.Hour).Format(time.RFC3339),
+							Duration:    600,
+							Replacement: apiRef{Id: firstSourceOfType(client, "slate")},
+						})
+						require.NoError(t, err)
+					}
+				},
+				Config: service + testAccVirtualChannelScheduleConfig(day, map[int]string{19: "movie", 21: "night"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "slots.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "slots.1.name", "night"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.Attributes["slots.0.id"]; id != movieID {
+							return fmt.Errorf("the unchanged slot was re-created: ID %s, was %s", id, movieID)
+						}
+						if len(client.slots) != 3 {
+							return fmt.Errorf("expected the 2 slots of the window and the one outside of it, got %d slots", len(client.slots))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      service + strings.Replace(testAccVirtualChannelScheduleConfig(day, map[int]string{19: "movie", 21: "night"}), "duration       = 3600", "duration       = 9000", 1),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Overlapping Slots`),
+			},
+			{
+				Config: service,
+				Check: func(*terraform.State) error {
+					if len(client.slots) != 1 {
+						return fmt.Errorf("expected only the slot outside of the window to be left, got %d slots", len(client.slots))
+					}
+					for key := range client.slots {
+						if key.id != outside.Id {
+							return fmt.Errorf("slot %d of the window was not deleted", key.id)
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// firstSourceOfType returns the ID of a source of the given type of the fake.
+func firstSourceOfType(client *fakeClient, sourceType string) uint {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	for id, source := range client.sources {
+		if source.Type == sourceType {
+			return id
+		}
+	}
+	return 0
+}
@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// NewVirtualChannelSlotResource is a helper function to simplify the provider implementation.
+func NewVirtualChannelSlotResource() resource.Resource {
+	return &serviceSlotResource{
+		typeName:    "virtual_channel_slot",
+		service:     "virtual channel",
+		replacement: "The live, asset or slate source played on the channel during the slot.",
+		api: slotMethods{
+			create: apiClient.CreateVirtualChannelSlot,
+			get:    apiClient.GetVirtualChannelSlot,
+			update: apiClient.UpdateVirtualChannelSlot,
+			delete: apiClient.DeleteVirtualChannelSlot,
+		},
+	}
+}